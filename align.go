@@ -0,0 +1,177 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package text
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+)
+
+// Align defines text alignment.
+type Align int
+
+// Text alignments.
+const (
+	AlignLeft Align = iota
+	AlignRight
+	AlignCenter
+)
+
+var aligns = map[Align]string{
+	AlignLeft:   "left",
+	AlignRight:  "right",
+	AlignCenter: "center",
+}
+
+func (a Align) String() string {
+	name, ok := aligns[a]
+	if ok {
+		return name
+	}
+	return fmt.Sprintf("{Align %d}", a)
+}
+
+// PadOptions define how text is padded to a display width.
+type PadOptions struct {
+	// Align specifies the alignment of the text inside the padded
+	// area.
+	Align Align
+	// Fill specifies the rune used for padding. The zero value pads
+	// with space (u0020).
+	Fill rune
+	// BG specifies an optional background color for the padding
+	// cells.
+	BG color.Color
+}
+
+// Pad pads the text to exactly width terminal cells. The text is
+// aligned inside the padded area according to the alignment option
+// and the remaining cells are filled with the fill rune. If the text
+// is wider than width, it is truncated to width.
+func (text *Text) Pad(width int, opts PadOptions) *Text {
+	text.Truncate(width)
+
+	space := width - text.Width()
+	if space <= 0 {
+		return text
+	}
+
+	var left, right int
+	switch opts.Align {
+	case AlignRight:
+		left = space
+	case AlignCenter:
+		left = space / 2
+		right = space - left
+	default:
+		right = space
+	}
+
+	var spans []Span
+	if left > 0 {
+		spans = append(spans, fill(left, opts))
+	}
+	spans = append(spans, text.Spans...)
+	if right > 0 {
+		spans = append(spans, fill(right, opts))
+	}
+	text.Spans = spans
+
+	return text
+}
+
+// PadEnd pads the text to width cells by adding the padding after
+// the text. The text is aligned to the left.
+func (text *Text) PadEnd(width int) *Text {
+	return text.Pad(width, PadOptions{
+		Align: AlignLeft,
+	})
+}
+
+// PadStart pads the text to width cells by adding the padding before
+// the text. The text is aligned to the right.
+func (text *Text) PadStart(width int) *Text {
+	return text.Pad(width, PadOptions{
+		Align: AlignRight,
+	})
+}
+
+// Center pads the text to width cells, centering it.
+func (text *Text) Center(width int) *Text {
+	return text.Pad(width, PadOptions{
+		Align: AlignCenter,
+	})
+}
+
+func fill(width int, opts PadOptions) Span {
+	r := opts.Fill
+	if r == 0 {
+		r = ' '
+	}
+	rw := RuneWidth(r)
+	if rw == 0 {
+		r = ' '
+		rw = 1
+	}
+	content := strings.Repeat(string(r), width/rw)
+	if width%rw != 0 {
+		content += strings.Repeat(" ", width%rw)
+	}
+	return Span{
+		BG:      opts.BG,
+		Content: content,
+	}
+}
+
+// Truncate truncates the text to at most width terminal cells. A
+// wide character that does not fit into the remaining space is
+// dropped, and the result is narrower than width.
+func (text *Text) Truncate(width int) *Text {
+	text.Spans = truncate(text.Spans, &width)
+	return text
+}
+
+func truncate(spans []Span, width *int) []Span {
+	for idx, span := range spans {
+		if span.Link != nil {
+			w := span.Link.Width()
+			if w <= *width {
+				*width -= w
+				continue
+			}
+			span.Link = &Text{
+				Spans: truncate(span.Link.Spans, width),
+			}
+			result := append([]Span{}, spans[:idx]...)
+			return append(result, span)
+		}
+
+		w := StringWidth(span.Content)
+		if w <= *width {
+			*width -= w
+			continue
+		}
+		var sb strings.Builder
+		for _, r := range span.Content {
+			rw := RuneWidth(r)
+			if rw > *width {
+				break
+			}
+			*width -= rw
+			sb.WriteRune(r)
+		}
+		span.Content = sb.String()
+		result := append([]Span{}, spans[:idx]...)
+		if len(span.Content) > 0 {
+			result = append(result, span)
+		}
+		*width = 0
+		return result
+	}
+	return spans
+}
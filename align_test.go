@@ -0,0 +1,140 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package text
+
+import (
+	"image/color"
+	"testing"
+)
+
+var widthTests = []struct {
+	input string
+	width int
+}{
+	{"", 0},
+	{"Hello", 5},
+	{"日本語", 6},
+	{"é", 1},
+	{"Ａ1", 3},
+	{"\t", 0},
+}
+
+func TestWidth(t *testing.T) {
+	for idx, test := range widthTests {
+		w := StringWidth(test.input)
+		if w != test.width {
+			t.Errorf("%d StringWidth(%q): got %v, expected %v",
+				idx, test.input, w, test.width)
+		}
+	}
+	txt := New().Plain("a").Link("https://example.com/", New().Bold("日本"))
+	if w := txt.Width(); w != 5 {
+		t.Errorf("Width: got %v, expected 5", w)
+	}
+}
+
+var padTests = []struct {
+	text   *Text
+	width  int
+	opts   PadOptions
+	result string
+}{
+	{
+		text:   New().Plain("abc"),
+		width:  6,
+		result: "abc   ",
+	},
+	{
+		text:  New().Plain("abc"),
+		width: 6,
+		opts: PadOptions{
+			Align: AlignRight,
+		},
+		result: "   abc",
+	},
+	{
+		text:  New().Plain("abc"),
+		width: 8,
+		opts: PadOptions{
+			Align: AlignCenter,
+			Fill:  '*',
+		},
+		result: "**abc***",
+	},
+	{
+		text:  New().Plain("Chapter 1 "),
+		width: 16,
+		opts: PadOptions{
+			Fill: '.',
+		},
+		result: "Chapter 1 ......",
+	},
+	{
+		text:  New().Plain("日本語"),
+		width: 5,
+		opts: PadOptions{
+			Fill: '-',
+		},
+		result: "日本-",
+	},
+	{
+		text:  New().Plain("ab"),
+		width: 5,
+		opts: PadOptions{
+			Fill: '日',
+		},
+		result: "ab日 ",
+	},
+	{
+		text:   New().Bold("bold").Plain("plain"),
+		width:  6,
+		result: "boldpl",
+	},
+}
+
+func TestPad(t *testing.T) {
+	for idx, test := range padTests {
+		txt := test.text.Pad(test.width, test.opts)
+		var result string
+		for _, span := range txt.Spans {
+			result += span.Content
+		}
+		if result != test.result {
+			t.Errorf("%d Pad: got %q, expected %q", idx, result, test.result)
+		}
+		if w := txt.Width(); w != test.width {
+			t.Errorf("%d Pad: got width %v, expected %v", idx, w, test.width)
+		}
+	}
+}
+
+func TestPadHelpers(t *testing.T) {
+	for idx, txt := range []*Text{
+		New().Plain("ab").PadEnd(5),
+		New().Plain("ab").PadStart(5),
+		New().Plain("ab").Center(5),
+	} {
+		var result string
+		for _, span := range txt.Spans {
+			result += span.Content
+		}
+		expected := []string{"ab   ", "   ab", " ab  "}[idx]
+		if result != expected {
+			t.Errorf("%d: got %q, expected %q", idx, result, expected)
+		}
+	}
+}
+
+func TestPadBG(t *testing.T) {
+	txt := New().Plain("row").Pad(5, PadOptions{
+		BG: color.NRGBA{R: 0x44, G: 0x77, B: 0xAA, A: 0xff},
+	})
+	expected := "row\x1b[48;2;68;119;170m  \x1b[0m"
+	if ansi := txt.ANSI(); ansi != expected {
+		t.Errorf("ANSI: got %q, expected %q", ansi, expected)
+	}
+}
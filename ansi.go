@@ -0,0 +1,66 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package text
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+)
+
+// ANSI creates ANSI terminal representation of the text. The styles
+// and 24-bit colors are rendered with SGR escape sequences and links
+// with OSC 8 hyperlink sequences.
+func (text *Text) ANSI() string {
+	var sb strings.Builder
+
+	for _, span := range text.Spans {
+		if span.Link != nil {
+			sb.WriteString("\x1b]8;;" + span.Content + "\x1b\\")
+			sb.WriteString(span.Link.ANSI())
+			sb.WriteString("\x1b]8;;\x1b\\")
+			continue
+		}
+
+		var params []string
+		if span.Bold {
+			params = append(params, "1")
+		}
+		if span.Oblique {
+			params = append(params, "3")
+		}
+		if span.FG != nil {
+			c := NRGBA(span.FG)
+			params = append(params, fmt.Sprintf("38;2;%d;%d;%d", c.R, c.G, c.B))
+		}
+		if span.BG != nil {
+			c := NRGBA(span.BG)
+			params = append(params, fmt.Sprintf("48;2;%d;%d;%d", c.R, c.G, c.B))
+		}
+		if len(params) == 0 {
+			sb.WriteString(span.Content)
+			continue
+		}
+		sb.WriteString("\x1b[" + strings.Join(params, ";") + "m")
+		sb.WriteString(span.Content)
+		sb.WriteString("\x1b[0m")
+	}
+
+	return sb.String()
+}
+
+// NRGBA converts the color c to a non-alpha-premultiplied color.
+func NRGBA(c color.Color) color.NRGBA {
+	return color.NRGBAModel.Convert(c).(color.NRGBA)
+}
+
+// HexColor formats the color c as a #rrggbb hex color for HTML and
+// SVG. The alpha component is ignored.
+func HexColor(c color.Color) string {
+	n := NRGBA(c)
+	return fmt.Sprintf("#%02x%02x%02x", n.R, n.G, n.B)
+}
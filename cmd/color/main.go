@@ -1,5 +1,5 @@
 //
-// Copyright (c) 2024 Markku Rossi
+// Copyright (c) 2024-2026 Markku Rossi
 //
 // All rights reserved.
 //
@@ -14,8 +14,8 @@ import (
 	"image/png"
 	"os"
 
+	"github.com/markkurossi/text"
 	cs "github.com/markkurossi/text/color"
-	"golang.org/x/image/font"
 	"golang.org/x/image/font/basicfont"
 	"golang.org/x/image/math/fixed"
 )
@@ -29,6 +29,8 @@ var (
 
 	white = color.RGBA{0xff, 0xff, 0xff, 0xff}
 	black = color.RGBA{0x00, 0x00, 0x00, 0xff}
+
+	renderer = text.NewRenderer(basicfont.Face7x13)
 )
 
 func main() {
@@ -126,20 +128,13 @@ func NRGBAToRGBA(c color.NRGBA) color.RGBA {
 }
 
 func drawString(img *image.RGBA, x, y int, c color.RGBA, str string) {
-	wPx := len(str) * basicfont.Face7x13.Advance
+	t := text.New().Plain(str)
+
+	wPx := renderer.Advance(t).Round()
 	hPx := basicfont.Face7x13.Ascent / 2
 
-	point := fixed.Point26_6{
-		X: fixed.I(x - wPx/2),
-		Y: fixed.I(y + hPx),
-	}
-	d := &font.Drawer{
-		Dst:  img,
-		Src:  image.NewUniform(c),
-		Face: basicfont.Face7x13,
-		Dot:  point,
-	}
-	d.DrawString(str)
+	renderer.Color = c
+	renderer.Draw(img, fixed.P(x-wPx/2, y+hPx), t)
 }
 
 type circle struct {
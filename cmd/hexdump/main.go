@@ -0,0 +1,613 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/markkurossi/text/hexdump"
+	"github.com/markkurossi/text/literal"
+)
+
+var commands = []struct {
+	name string
+	args string
+	help string
+	run  func(fs *flag.FlagSet, args []string) error
+}{
+	{
+		name: "dump",
+		args: "[file]",
+		help: "dump file, or standard input, as hexdump or byte literal",
+		run:  cmdDump,
+	},
+	{
+		name: "undump",
+		args: "[file]",
+		help: "convert hexdump or byte literal back to binary",
+		run:  cmdUndump,
+	},
+	{
+		name: "diff",
+		args: "file1 file2",
+		help: "show the differences of two files as hexdumps",
+		run:  cmdDiff,
+	},
+	{
+		name: "patch",
+		args: "dump file",
+		help: "apply the bytes of an edited hexdump to file",
+		run:  cmdPatch,
+	},
+}
+
+// errDiffer is returned by the diff command when the inputs differ.
+var errDiffer = errors.New("inputs differ")
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+	for _, cmd := range commands {
+		if cmd.name != flag.Arg(0) {
+			continue
+		}
+		fs := flag.NewFlagSet(cmd.name, flag.ExitOnError)
+		fs.Usage = func() {
+			fmt.Fprintf(fs.Output(), "Usage: hexdump %s [options] %s\n",
+				cmd.name, cmd.args)
+			fs.PrintDefaults()
+		}
+		err := cmd.run(fs, flag.Args()[1:])
+		if err == errDiffer {
+			os.Exit(1)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "hexdump %s: %s\n", cmd.name, err)
+			os.Exit(2)
+		}
+		return
+	}
+	fmt.Fprintf(os.Stderr, "hexdump: unknown command: %s\n", flag.Arg(0))
+	usage()
+	os.Exit(2)
+}
+
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage: hexdump command [options] [args]\n\nCommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(out, "  %-8s %s\n", cmd.name, cmd.help)
+	}
+	fmt.Fprintf(out,
+		"\nRun 'hexdump command -h' for the command options.\n")
+}
+
+// dumpFlags defines the dump layout options shared by the dump and
+// diff commands.
+type dumpFlags struct {
+	cols    int
+	group   int
+	little  bool
+	base    int
+	width   int
+	upper   bool
+	noASCII bool
+	charset string
+	skip    int64
+	length  int64
+}
+
+func (f *dumpFlags) register(fs *flag.FlagSet) {
+	fs.IntVar(&f.cols, "c", 16, "bytes per line")
+	fs.IntVar(&f.group, "g", 1, "bytes per group: 1, 2, 4, or 8")
+	fs.BoolVar(&f.little, "e", false, "little-endian groups")
+	fs.IntVar(&f.base, "base", 16, "number base of groups: 16, 8, or 2")
+	fs.IntVar(&f.width, "w", 8, "offset width in hex digits")
+	fs.BoolVar(&f.upper, "u", false, "uppercase hex digits")
+	fs.BoolVar(&f.noASCII, "no-text", false, "omit the text pane")
+	fs.StringVar(&f.charset, "charset", "ascii", "text pane charset: "+
+		charsetNames())
+	fs.Int64Var(&f.skip, "s", 0, "start from the input offset")
+	fs.Int64Var(&f.length, "l", -1, "stop after the number of bytes")
+}
+
+func (f *dumpFlags) dumper() (hexdump.Dumper, error) {
+	charset, ok := charsets[f.charset]
+	if !ok {
+		return hexdump.Dumper{}, fmt.Errorf("unknown charset: %s", f.charset)
+	}
+	switch f.group {
+	case 1, 2, 4, 8:
+	default:
+		return hexdump.Dumper{}, fmt.Errorf("invalid group size: %d", f.group)
+	}
+	switch f.base {
+	case 2, 8, 16:
+	default:
+		return hexdump.Dumper{}, fmt.Errorf("invalid base: %d", f.base)
+	}
+	if f.cols <= 0 {
+		return hexdump.Dumper{}, fmt.Errorf("invalid columns: %d", f.cols)
+	}
+	return hexdump.Dumper{
+		BytesPerLine: f.cols,
+		Group:        f.group,
+		LittleEndian: f.little,
+		Base:         f.base,
+		OffsetWidth:  f.width,
+		Offset:       uint64(f.skip),
+		Uppercase:    f.upper,
+		NoASCII:      f.noASCII,
+		Charset:      charset,
+	}, nil
+}
+
+// open opens the input range of the file. The file name "-" and
+// the empty name read the standard input.
+func (f *dumpFlags) open(name string) (io.ReadCloser, error) {
+	var file *os.File
+	if len(name) == 0 || name == "-" {
+		file = os.Stdin
+	} else {
+		var err error
+		file, err = os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if f.skip > 0 {
+		_, err := file.Seek(f.skip, io.SeekStart)
+		if err != nil {
+			_, err = io.CopyN(io.Discard, file, f.skip)
+		}
+		if err != nil && err != io.EOF {
+			file.Close()
+			return nil, err
+		}
+	}
+	if f.length < 0 {
+		return file, nil
+	}
+	return &limitedFile{
+		Reader: io.LimitReader(file, f.length),
+		Closer: file,
+	}, nil
+}
+
+type limitedFile struct {
+	io.Reader
+	io.Closer
+}
+
+func (f *dumpFlags) read(name string) ([]byte, error) {
+	r, err := f.open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+var charsets = make(map[string]hexdump.Charset)
+
+// formats maps the undump input format names to the hexdump and byte
+// literal formats. The plain hex of xxd -p is named "plain" since
+// "hex" is the hexdump format.
+var formats = map[string]interface{}{
+	"plain": literal.FormatHex,
+}
+
+func init() {
+	for c := hexdump.CharsetASCII; c <= hexdump.CharsetUTF16LE; c++ {
+		charsets[c.String()] = c
+	}
+	for f := hexdump.FormatAuto; f <= hexdump.FormatWireshark; f++ {
+		formats[f.String()] = f
+	}
+	for f := literal.FormatC; f <= literal.FormatEscaped; f++ {
+		formats[f.String()] = f
+	}
+}
+
+func charsetNames() string {
+	var result []string
+	for name := range charsets {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return strings.Join(result, ", ")
+}
+
+func formatNames() string {
+	var result []string
+	for name := range formats {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return strings.Join(result, ", ")
+}
+
+func output(name string) (io.WriteCloser, error) {
+	if len(name) == 0 || name == "-" {
+		return nopCloser{os.Stdout}, nil
+	}
+	return os.Create(name)
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+func cmdDump(fs *flag.FlagSet, args []string) error {
+	var df dumpFlags
+	df.register(fs)
+	format := fs.String("f", "dump",
+		"output format: dump, c, go, escaped, or plain")
+	name := fs.String("n", "", "variable name of c and go literals")
+	out := fs.String("o", "", "output file")
+	fs.Parse(args)
+	if fs.NArg() > 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	d, err := df.dumper()
+	if err != nil {
+		return err
+	}
+	f, ok := formats[*format].(literal.Format)
+	if !ok && *format != "dump" {
+		return fmt.Errorf("unknown output format: %s", *format)
+	}
+	r, err := df.open(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	w, err := output(*out)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		dw := d.Writer(w)
+		_, err = io.Copy(dw, r)
+		if err == nil {
+			err = dw.Close()
+		}
+	} else {
+		var data []byte
+		data, err = io.ReadAll(r)
+		if err == nil {
+			g := literal.NewGenerator(f)
+			g.Name = *name
+			if len(g.Name) == 0 && f == literal.FormatC &&
+				len(fs.Arg(0)) > 0 && fs.Arg(0) != "-" {
+				g.Name = cName(fs.Arg(0))
+			}
+			if isFlagSet(fs, "c") {
+				g.BytesPerLine = df.cols
+			}
+			g.Uppercase = df.upper
+			_, err = io.WriteString(w, g.Generate(data))
+		}
+	}
+	if cerr := w.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// cName creates a C variable name from the file name, as xxd -i
+// does.
+func cName(file string) string {
+	var sb strings.Builder
+	for i, r := range filepath.Base(file) {
+		switch {
+		case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', r == '_':
+		case '0' <= r && r <= '9':
+			if i == 0 {
+				sb.WriteString("__")
+			}
+		default:
+			r = '_'
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+func isFlagSet(fs *flag.FlagSet, name string) bool {
+	var set bool
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// parserFlags defines the hexdump parsing options shared by the
+// undump and patch commands.
+type parserFlags struct {
+	format string
+	little bool
+	base   int
+	strict bool
+	gaps   string
+}
+
+func (f *parserFlags) register(fs *flag.FlagSet, gaps bool) {
+	fs.StringVar(&f.format, "f", "auto", "input format: "+formatNames())
+	fs.BoolVar(&f.little, "e", false, "little-endian hex groups")
+	fs.IntVar(&f.base, "base", 16, "number base of groups: 16, 8, or 2")
+	fs.BoolVar(&f.strict, "strict", false, "reject malformed lines")
+	if gaps {
+		fs.StringVar(&f.gaps, "gaps", "error",
+			"offset gap handling: error, fill, or ignore")
+	}
+}
+
+func (f *parserFlags) parser() (*hexdump.Parser, error) {
+	switch f.base {
+	case 2, 8, 16:
+	default:
+		return nil, fmt.Errorf("invalid base: %d", f.base)
+	}
+	p := &hexdump.Parser{
+		LittleEndian: f.little,
+		Base:         f.base,
+		Strict:       f.strict,
+	}
+	if format, ok := formats[f.format].(hexdump.Format); ok {
+		p.Format = format
+	} else if _, ok := formats[f.format]; !ok {
+		return nil, fmt.Errorf("unknown input format: %s", f.format)
+	}
+	switch f.gaps {
+	case "", "error":
+		p.Gaps = hexdump.GapError
+	case "fill":
+		p.Gaps = hexdump.GapFill
+	case "ignore":
+		p.Gaps = hexdump.GapIgnore
+	default:
+		return nil, fmt.Errorf("unknown gap mode: %s", f.gaps)
+	}
+	return p, nil
+}
+
+// parse parses the input data. The automatic format detection tries
+// the hexdump formats first, and the byte literal formats if data
+// does not have any lines that look like hexdump data lines. The
+// malformed hexdumps are errors.
+func (f *parserFlags) parse(data []byte) ([]byte, error) {
+	if format, ok := formats[f.format].(literal.Format); ok {
+		return literal.ParseFormat(data, format)
+	}
+	p, err := f.parser()
+	if err != nil {
+		return nil, err
+	}
+	d := p.Decoder(bytes.NewReader(data))
+	result, err := io.ReadAll(d)
+	if f.format != "auto" || d.Format() != hexdump.FormatAuto ||
+		len(bytes.TrimSpace(data)) == 0 || f.base != 16 {
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+	lresult, lerr := literal.Parse(data)
+	if lerr != nil {
+		return nil, err
+	}
+	return lresult, nil
+}
+
+func readInput(name string) ([]byte, error) {
+	if len(name) == 0 || name == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(name)
+}
+
+func cmdUndump(fs *flag.FlagSet, args []string) error {
+	var pf parserFlags
+	pf.register(fs, true)
+	out := fs.String("o", "", "output file")
+	fs.Parse(args)
+	if fs.NArg() > 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	data, err := readInput(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	result, err := pf.parse(data)
+	if err != nil {
+		return err
+	}
+	w, err := output(*out)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(result)
+	if cerr := w.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func cmdDiff(fs *flag.FlagSet, args []string) error {
+	var df dumpFlags
+	df.register(fs)
+	interleaved := fs.Bool("i", false, "interleaved layout")
+	insertions := fs.Bool("insertions", false,
+		"align inserted and deleted bytes")
+	context := fs.Int("context", 1, "identical lines around differences")
+	colors := fs.String("color", "auto", "colors: auto, always, or never")
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	d, err := df.dumper()
+	if err != nil {
+		return err
+	}
+	differ := &hexdump.Differ{
+		Dumper:     d,
+		Insertions: *insertions,
+		Context:    *context,
+	}
+	if *interleaved {
+		differ.Layout = hexdump.DiffInterleaved
+	}
+
+	var ansi bool
+	switch *colors {
+	case "auto":
+		ansi = isTerminal(os.Stdout)
+	case "always":
+		ansi = true
+	case "never":
+	default:
+		return fmt.Errorf("unknown color mode: %s", *colors)
+	}
+
+	a, err := df.read(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	b, err := df.read(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(a, b) {
+		return nil
+	}
+	result := differ.Diff(a, b)
+	if ansi {
+		fmt.Print(result.ANSI())
+	} else {
+		fmt.Print(result.String())
+	}
+	return errDiffer
+}
+
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+func cmdPatch(fs *flag.FlagSet, args []string) error {
+	var pf parserFlags
+	pf.register(fs, false)
+	out := fs.String("o", "", "output file instead of patching file in place")
+	grow := fs.Bool("grow", false, "allow patches to extend the file")
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	if _, ok := formats[pf.format].(literal.Format); ok {
+		return fmt.Errorf("patch needs a hexdump format: %s", pf.format)
+	}
+
+	dump, err := readInput(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	p, err := pf.parser()
+	if err != nil {
+		return err
+	}
+	runs, err := p.ParseSparse(dump)
+	if err != nil {
+		return err
+	}
+	if len(runs) == 0 {
+		return fmt.Errorf("%s: no data lines", fs.Arg(0))
+	}
+
+	file := fs.Arg(1)
+	fi, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	for offset, run := range runs {
+		end := offset + uint64(len(run))
+		if end > uint64(len(data)) && !*grow {
+			return fmt.Errorf("%s: offsets %x-%x are past the end of %s "+
+				"(%d bytes)", fs.Arg(0), offset, end-1, file, len(data))
+		}
+		if end > uint64(len(data)) {
+			data = append(data, make([]byte, end-uint64(len(data)))...)
+		}
+		copy(data[offset:], run)
+	}
+
+	if len(*out) > 0 {
+		return os.WriteFile(*out, data, fi.Mode().Perm())
+	}
+	return replaceFile(file, data, fi.Mode().Perm())
+}
+
+// replaceFile replaces the contents of the file with data. The data
+// is written to a temporary file in the same directory which is then
+// renamed over the file, so the original file is intact if the write
+// fails.
+func replaceFile(file string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(file), "."+filepath.Base(file)+
+		".*")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), perm)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), file)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
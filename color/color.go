@@ -1,5 +1,5 @@
 //
-// Copyright (c) 2024 Markku Rossi
+// Copyright (c) 2024-2026 Markku Rossi
 //
 // All rights reserved.
 //
@@ -67,6 +67,53 @@ type Scheme struct {
 	BadData *Color
 }
 
+// Quantize returns the scheme color for the relative position pos
+// [0...1] by dividing the range into equal sized intervals, one for
+// each scheme color. The positions outside the range are clamped to
+// the first and last color.
+func (scheme *Scheme) Quantize(pos float64) *Color {
+	n := len(scheme.Colors)
+	if !(pos > 0) {
+		return scheme.Colors[0]
+	}
+	idx := int(pos * float64(n))
+	if idx >= n {
+		idx = n - 1
+	}
+	return scheme.Colors[idx]
+}
+
+// Interpolate returns the color for the relative position pos
+// [0...1] by linearly interpolating between the scheme's background
+// colors. The positions outside the range are clamped to the first
+// and last color.
+func (scheme *Scheme) Interpolate(pos float64) color.NRGBA {
+	n := len(scheme.Colors)
+	if n == 1 || !(pos > 0) {
+		return scheme.Colors[0].BG
+	}
+	if pos >= 1 {
+		return scheme.Colors[n-1].BG
+	}
+	x := pos * float64(n-1)
+	idx := int(x)
+	fract := x - float64(idx)
+
+	from := scheme.Colors[idx].BG
+	to := scheme.Colors[idx+1].BG
+
+	return color.NRGBA{
+		R: lerp(from.R, to.R, fract),
+		G: lerp(from.G, to.G, fract),
+		B: lerp(from.B, to.B, fract),
+		A: lerp(from.A, to.A, fract),
+	}
+}
+
+func lerp(from, to uint8, fract float64) uint8 {
+	return uint8(float64(from) + (float64(to)-float64(from))*fract + 0.5)
+}
+
 var (
 	// Qualitative color schemes
 
@@ -1,5 +1,5 @@
 //
-// Copyright (c) 2024 Markku Rossi
+// Copyright (c) 2024-2026 Markku Rossi
 //
 // All rights reserved.
 //
@@ -41,3 +41,50 @@ func testSchemeBG(t *testing.T, scheme *Scheme) {
 		fmt.Printf(" %2d: bg=%v[%v]\n", idx, bgName, l)
 	}
 }
+
+func TestInterpolate(t *testing.T) {
+	scheme := &Scheme{
+		Colors: []*Color{
+			NewColor(Black, 0x00, 0x00, 0x00, ""),
+			NewColor(Black, 0x80, 0x40, 0x20, ""),
+			NewColor(Black, 0xff, 0xff, 0xff, ""),
+		},
+	}
+	tests := []struct {
+		pos float64
+		c   color.NRGBA
+	}{
+		{-1, color.NRGBA{0x00, 0x00, 0x00, 0xff}},
+		{0, color.NRGBA{0x00, 0x00, 0x00, 0xff}},
+		{0.25, color.NRGBA{0x40, 0x20, 0x10, 0xff}},
+		{0.5, color.NRGBA{0x80, 0x40, 0x20, 0xff}},
+		{1, color.NRGBA{0xff, 0xff, 0xff, 0xff}},
+		{2, color.NRGBA{0xff, 0xff, 0xff, 0xff}},
+	}
+	for _, test := range tests {
+		c := scheme.Interpolate(test.pos)
+		if c != test.c {
+			t.Errorf("Interpolate(%v): got %v, expected %v", test.pos, c, test.c)
+		}
+	}
+}
+
+func TestQuantize(t *testing.T) {
+	tests := []struct {
+		pos float64
+		idx int
+	}{
+		{-1, 0}, {0, 0}, {0.24, 0}, {0.25, 1}, {0.5, 2}, {0.99, 3}, {1, 3},
+		{2, 3},
+	}
+	scheme := &Scheme{
+		Colors: Bright.Colors[:4],
+	}
+	for _, test := range tests {
+		c := scheme.Quantize(test.pos)
+		if c != scheme.Colors[test.idx] {
+			t.Errorf("Quantize(%v): got %v, expected %v",
+				test.pos, c.Name, scheme.Colors[test.idx].Name)
+		}
+	}
+}
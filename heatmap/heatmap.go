@@ -0,0 +1,465 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+// Package heatmap implements calendar heatmaps of daily values. The
+// calendar is a grid of weeks × weekdays where each day is colored
+// by its value's quantile level.
+package heatmap
+
+import (
+	"fmt"
+	"image/color"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/markkurossi/text"
+	cs "github.com/markkurossi/text/color"
+)
+
+// Mode defines how calendar cells are rendered in terminals.
+type Mode int
+
+// Terminal rendering modes.
+const (
+	// Shades renders cells with shade blocks (░▒▓█) that have the
+	// level color as the foreground color. The shades keep the
+	// levels distinguishable in monochrome terminals.
+	Shades Mode = iota
+	// Backgrounds renders cells with the level color as the
+	// background color.
+	Backgrounds
+)
+
+var modes = map[Mode]string{
+	Shades:      "shades",
+	Backgrounds: "backgrounds",
+}
+
+func (m Mode) String() string {
+	name, ok := modes[m]
+	if ok {
+		return name
+	}
+	return fmt.Sprintf("{Mode %d}", m)
+}
+
+// shades define the cell runes for the Shades mode.
+var shades = []rune{'·', '░', '▒', '▓', '█'}
+
+// Calendar implements a calendar heatmap of daily values.
+type Calendar struct {
+	// Scheme specifies a sequential color scheme for the levels.
+	Scheme *cs.Scheme
+	// Levels specifies the number of levels. The level 0 is for
+	// days without a positive value and the positive values are
+	// divided into the remaining levels by their quantiles.
+	Levels int
+	// WeekStart specifies the first day of the week.
+	WeekStart time.Weekday
+	// Mode specifies how cells are rendered in terminals.
+	Mode Mode
+	// Labels specifies if month and weekday labels are rendered.
+	Labels bool
+	// Legend specifies if the level legend strip is rendered.
+	Legend bool
+
+	values map[time.Time]float64
+}
+
+// New creates a new calendar with the YlOrBr color scheme and 5
+// levels.
+func New() *Calendar {
+	return &Calendar{
+		Scheme: cs.YlOrBr,
+		Levels: 5,
+		Labels: true,
+		Legend: true,
+		values: make(map[time.Time]float64),
+	}
+}
+
+func day(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// Set sets the value of the day t.
+func (c *Calendar) Set(t time.Time, v float64) {
+	if c.values == nil {
+		c.values = make(map[time.Time]float64)
+	}
+	c.values[day(t)] = v
+}
+
+// Add adds v to the value of the day t.
+func (c *Calendar) Add(t time.Time, v float64) {
+	if c.values == nil {
+		c.values = make(map[time.Time]float64)
+	}
+	c.values[day(t)] += v
+}
+
+// Value returns the value of the day t.
+func (c *Calendar) Value(t time.Time) (float64, bool) {
+	v, ok := c.values[day(t)]
+	return v, ok
+}
+
+func (c *Calendar) levels() int {
+	if c.Levels < 2 {
+		return 2
+	}
+	return c.Levels
+}
+
+// grid defines the calendar layout for a date range.
+type grid struct {
+	start      time.Time
+	from       time.Time
+	to         time.Time
+	weeks      int
+	thresholds []float64
+}
+
+func (c *Calendar) grid(from, to time.Time) *grid {
+	from = day(from)
+	to = day(to)
+	if to.Before(from) {
+		from, to = to, from
+	}
+	offset := (int(from.Weekday()) - int(c.WeekStart) + 7) % 7
+	start := from.AddDate(0, 0, -offset)
+	days := int(to.Sub(start).Hours()/24+0.5) + 1
+
+	g := &grid{
+		start: start,
+		from:  from,
+		to:    to,
+		weeks: (days + 6) / 7,
+	}
+
+	var values []float64
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		v, ok := c.values[d]
+		if ok && v > 0 {
+			values = append(values, v)
+		}
+	}
+	sort.Float64s(values)
+	if len(values) > 0 {
+		n := c.levels() - 1
+		for k := 1; k < n; k++ {
+			g.thresholds = append(g.thresholds,
+				quantile(values, float64(k)/float64(n)))
+		}
+	}
+	return g
+}
+
+func quantile(sorted []float64, p float64) float64 {
+	x := p * float64(len(sorted)-1)
+	idx := int(x)
+	if idx >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[idx] + (sorted[idx+1]-sorted[idx])*(x-float64(idx))
+}
+
+// day returns the date of the grid cell and a flag indicating if the
+// date is in the grid's date range.
+func (g *grid) day(week, weekday int) (time.Time, bool) {
+	d := g.start.AddDate(0, 0, week*7+weekday)
+	return d, !d.Before(g.from) && !d.After(g.to)
+}
+
+// level returns the level of the value v.
+func (g *grid) level(v float64, ok bool) int {
+	if !ok || !(v > 0) {
+		return 0
+	}
+	level := 1
+	for _, t := range g.thresholds {
+		if v >= t {
+			level++
+		}
+	}
+	return level
+}
+
+// color returns the color of the level.
+func (c *Calendar) color(level int) color.Color {
+	if c.Scheme == nil || len(c.Scheme.Colors) == 0 {
+		return nil
+	}
+	return c.Scheme.Interpolate(float64(level) / float64(c.levels()-1))
+}
+
+func (c *Calendar) cell(level int) text.Span {
+	col := c.color(level)
+	if c.Mode == Backgrounds {
+		return text.Span{
+			BG:      col,
+			Content: "  ",
+		}
+	}
+	idx := level * (len(shades) - 1) / (c.levels() - 1)
+	return text.Span{
+		FG:      col,
+		Content: string(shades[idx]) + " ",
+	}
+}
+
+func (c *Calendar) weekday(row int) time.Weekday {
+	return time.Weekday((int(c.WeekStart) + row) % 7)
+}
+
+// months returns the month label positions of the grid. The
+// function returns a map from week column to month.
+func (g *grid) months() map[int]time.Month {
+	result := make(map[int]time.Month)
+	last := -3
+	for week := 0; week < g.weeks; week++ {
+		for wd := 0; wd < 7; wd++ {
+			d, ok := g.day(week, wd)
+			if ok && (d.Day() == 1 || d.Equal(g.from)) {
+				if week-last >= 2 {
+					result[week] = d.Month()
+					last = week
+				}
+				break
+			}
+		}
+	}
+	return result
+}
+
+// Text renders the calendar for the date range [from...to] for
+// terminals. The function returns the calendar lines. Use the text's
+// ANSI method for rendering the lines with terminal colors.
+func (c *Calendar) Text(from, to time.Time) []*text.Text {
+	g := c.grid(from, to)
+
+	var result []*text.Text
+	var indent string
+	if c.Labels {
+		indent = "    "
+		months := g.months()
+		var sb strings.Builder
+		sb.WriteString(indent)
+		for week := 0; week < g.weeks; week++ {
+			m, ok := months[week]
+			if ok {
+				label := m.String()[:3]
+				sb.WriteString(label)
+				if week+1 < g.weeks {
+					sb.WriteString(" ")
+				}
+				week++
+				continue
+			}
+			sb.WriteString("  ")
+		}
+		result = append(result,
+			text.New().Plain(strings.TrimRight(sb.String(), " ")))
+	}
+
+	for row := 0; row < 7; row++ {
+		line := text.New()
+		if c.Labels {
+			var label string
+			if row%2 == 1 {
+				label = c.weekday(row).String()[:3]
+			}
+			line.Plain(label).PadEnd(len(indent))
+		}
+		for week := 0; week < g.weeks; week++ {
+			d, ok := g.day(week, row)
+			if !ok {
+				line.Plain("  ")
+				continue
+			}
+			v, ok := c.values[d]
+			line.AppendSpan(c.cell(g.level(v, ok)))
+		}
+		result = append(result, line)
+	}
+
+	if c.Legend {
+		line := text.New().Plain(indent + "Less ")
+		for level := 0; level < c.levels(); level++ {
+			line.AppendSpan(c.cell(level))
+		}
+		result = append(result, line.Plain("More"))
+	}
+
+	return result
+}
+
+// HTML renders the calendar for the date range [from...to] as an
+// HTML <table>. Each day cell has a title attribute with the date
+// and value.
+func (c *Calendar) HTML(from, to time.Time) string {
+	g := c.grid(from, to)
+
+	var sb strings.Builder
+	sb.WriteString(`<table class="heatmap">`)
+
+	if c.Labels {
+		months := g.months()
+		sb.WriteString(`<tr><td></td>`)
+		for week := 0; week < g.weeks; week++ {
+			m, ok := months[week]
+			if !ok {
+				sb.WriteString(`<td></td>`)
+				continue
+			}
+			span := 1
+			for span < 4 && week+span < g.weeks {
+				if _, ok := months[week+span]; ok {
+					break
+				}
+				span++
+			}
+			fmt.Fprintf(&sb, `<td colspan="%d">%s</td>`,
+				span, m.String()[:3])
+			week += span - 1
+		}
+		sb.WriteString(`</tr>`)
+	}
+
+	for row := 0; row < 7; row++ {
+		sb.WriteString(`<tr>`)
+		if c.Labels {
+			sb.WriteString(`<td>`)
+			if row%2 == 1 {
+				sb.WriteString(c.weekday(row).String()[:3])
+			}
+			sb.WriteString(`</td>`)
+		}
+		for week := 0; week < g.weeks; week++ {
+			d, ok := g.day(week, row)
+			if !ok {
+				sb.WriteString(`<td></td>`)
+				continue
+			}
+			v, ok := c.values[d]
+			sb.WriteString(`<td`)
+			c.htmlStyle(&sb, g.level(v, ok))
+			fmt.Fprintf(&sb, ` title="%s: %g"></td>`,
+				d.Format("2006-01-02"), v)
+		}
+		sb.WriteString(`</tr>`)
+	}
+
+	if c.Legend {
+		sb.WriteString(`<tr><td></td><td colspan="2">Less</td>`)
+		for level := 0; level < c.levels(); level++ {
+			sb.WriteString(`<td`)
+			c.htmlStyle(&sb, level)
+			sb.WriteString(`></td>`)
+		}
+		sb.WriteString(`<td colspan="2">More</td></tr>`)
+	}
+
+	sb.WriteString(`</table>`)
+	return sb.String()
+}
+
+func (c *Calendar) htmlStyle(sb *strings.Builder, level int) {
+	col := c.color(level)
+	if col == nil {
+		return
+	}
+	fmt.Fprintf(sb, ` style="background-color:%s"`, text.HexColor(col))
+}
+
+// SVG geometry.
+const (
+	svgCell   = 10
+	svgGap    = 2
+	svgLabelX = 28
+	svgLabelY = 14
+)
+
+// SVG renders the calendar for the date range [from...to] as an SVG
+// image. Each day cell has a <title> element with the date and value.
+func (c *Calendar) SVG(from, to time.Time) string {
+	g := c.grid(from, to)
+
+	step := svgCell + svgGap
+	var x0, y0 int
+	if c.Labels {
+		x0 = svgLabelX
+		y0 = svgLabelY
+	}
+	width := x0 + g.weeks*step
+	height := y0 + 7*step
+	if c.Legend {
+		height += step + svgGap
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-size="9">`,
+		width, height)
+
+	if c.Labels {
+		months := g.months()
+		for week := 0; week < g.weeks; week++ {
+			m, ok := months[week]
+			if ok {
+				fmt.Fprintf(&sb, `<text x="%d" y="%d">%s</text>`,
+					x0+week*step, y0-4, m.String()[:3])
+			}
+		}
+		for row := 1; row < 7; row += 2 {
+			fmt.Fprintf(&sb, `<text x="0" y="%d">%s</text>`,
+				y0+row*step+svgCell-1, c.weekday(row).String()[:3])
+		}
+	}
+
+	for week := 0; week < g.weeks; week++ {
+		for row := 0; row < 7; row++ {
+			d, ok := g.day(week, row)
+			if !ok {
+				continue
+			}
+			v, ok := c.values[d]
+			c.svgRect(&sb, x0+week*step, y0+row*step, g.level(v, ok))
+			fmt.Fprintf(&sb, `<title>%s: %g</title></rect>`,
+				d.Format("2006-01-02"), v)
+		}
+	}
+
+	if c.Legend {
+		y := y0 + 7*step + svgGap
+		x := width - (c.levels()+2)*step - 4*step
+		if x < 0 {
+			x = 0
+		}
+		fmt.Fprintf(&sb, `<text x="%d" y="%d">Less</text>`,
+			x, y+svgCell-1)
+		x += 2*step + 4
+		for level := 0; level < c.levels(); level++ {
+			c.svgRect(&sb, x+level*step, y, level)
+			sb.WriteString(`</rect>`)
+		}
+		fmt.Fprintf(&sb, `<text x="%d" y="%d">More</text>`,
+			x+c.levels()*step+2, y+svgCell-1)
+	}
+
+	sb.WriteString(`</svg>`)
+	return sb.String()
+}
+
+func (c *Calendar) svgRect(sb *strings.Builder, x, y, level int) {
+	fmt.Fprintf(sb, `<rect x="%d" y="%d" width="%d" height="%d"`,
+		x, y, svgCell, svgCell)
+	if col := c.color(level); col != nil {
+		fmt.Fprintf(sb, ` fill="%s"`, text.HexColor(col))
+	}
+	sb.WriteString(`>`)
+}
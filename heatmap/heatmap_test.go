@@ -0,0 +1,158 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package heatmap
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestLevels(t *testing.T) {
+	c := New()
+	c.Set(date(2026, 3, 2), 1)
+	c.Set(date(2026, 3, 3), 2)
+	c.Set(date(2026, 3, 4), 3)
+	c.Set(date(2026, 3, 5), 4)
+	c.Set(date(2026, 3, 6), 5)
+	c.Set(date(2026, 3, 7), 0)
+
+	g := c.grid(date(2026, 3, 1), date(2026, 3, 8))
+	if g.weeks != 2 {
+		t.Errorf("weeks: got %v, expected 2", g.weeks)
+	}
+	expected := []int{0, 1, 2, 3, 4, 4, 0, 0}
+	for i, e := range expected {
+		d := date(2026, 3, 1+i)
+		v, ok := c.Value(d)
+		if l := g.level(v, ok); l != e {
+			t.Errorf("%s: got level %v, expected %v",
+				d.Format("2006-01-02"), l, e)
+		}
+	}
+}
+
+func TestZeroValue(t *testing.T) {
+	c := &Calendar{}
+	c.Add(date(2026, 3, 2), 1)
+	c.Add(date(2026, 3, 2), 2)
+	c.Set(date(2026, 3, 3), 4)
+	if v, ok := c.Value(date(2026, 3, 2)); !ok || v != 3 {
+		t.Errorf("got %v %v, expected 3 true", v, ok)
+	}
+	if v, ok := c.Value(date(2026, 3, 3)); !ok || v != 4 {
+		t.Errorf("got %v %v, expected 4 true", v, ok)
+	}
+}
+
+func TestText(t *testing.T) {
+	c := New()
+	c.Legend = false
+	c.WeekStart = time.Monday
+	for i := 0; i < 10; i++ {
+		c.Add(date(2026, 1, 26+i), float64(i))
+	}
+	lines := c.Text(date(2026, 1, 26), date(2026, 2, 8))
+	expected := []string{
+		"    Jan",
+		"    · █ ",
+		"Tue ░ █ ",
+		"    ░ █ ",
+		"Thu ▒ · ",
+		"    ▒ · ",
+		"Sat ▓ · ",
+		"    ▓ · ",
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("got %v lines, expected %v", len(lines), len(expected))
+	}
+	for idx, line := range lines {
+		if line.String() != expected[idx] {
+			t.Errorf("line %d: got %q, expected %q",
+				idx, line.String(), expected[idx])
+		}
+	}
+
+	c.Mode = Backgrounds
+	c.Labels = false
+	c.Legend = true
+	lines = c.Text(date(2026, 1, 26), date(2026, 2, 8))
+	levels := [][]int{
+		{0, 4}, {1, 4}, {1, 4}, {2, 0}, {2, 0}, {3, 0}, {3, 0},
+		{0, 1, 2, 3, 4},
+	}
+	if len(lines) != len(levels) {
+		t.Fatalf("got %v lines, expected %v", len(lines), len(levels))
+	}
+	for idx, line := range lines {
+		spans := line.Spans
+		if idx == len(lines)-1 {
+			// The legend has the Less and More labels around the
+			// level cells.
+			spans = spans[1 : len(spans)-1]
+		}
+		if len(spans) != len(levels[idx]) {
+			t.Fatalf("line %d: got %v cells, expected %v",
+				idx, len(spans), len(levels[idx]))
+		}
+		for i, span := range spans {
+			bg := c.color(levels[idx][i])
+			if span.Content != "  " || span.BG != bg {
+				t.Errorf("line %d: cell %d: got %q/%v, expected %q/%v",
+					idx, i, span.Content, span.BG, "  ", bg)
+			}
+		}
+	}
+}
+
+func TestHTML(t *testing.T) {
+	c := New()
+	c.Set(date(2026, 5, 1), 3)
+	html := c.HTML(date(2026, 5, 1), date(2026, 5, 31))
+	if !strings.HasPrefix(html, `<table class="heatmap">`) {
+		t.Errorf("invalid HTML: %s", html)
+	}
+	if !strings.Contains(html, `title="2026-05-01: 3"`) {
+		t.Errorf("HTML missing day title: %s", html)
+	}
+	checkXML(t, html)
+}
+
+func TestSVG(t *testing.T) {
+	c := New()
+	for i := 0; i < 90; i++ {
+		c.Set(date(2026, 1, 1).AddDate(0, 0, i), float64(i%5))
+	}
+	svg := c.SVG(date(2026, 1, 1), date(2026, 3, 31))
+	if !strings.Contains(svg, `<title>2026-03-31: 4</title>`) {
+		t.Errorf("SVG missing day title")
+	}
+	if strings.Count(svg, "<title>") != 90 {
+		t.Errorf("SVG: got %v days, expected 90", strings.Count(svg, "<title>"))
+	}
+	checkXML(t, svg)
+}
+
+func checkXML(t *testing.T, data string) {
+	t.Helper()
+	d := xml.NewDecoder(strings.NewReader(data))
+	for {
+		_, err := d.Token()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			t.Fatalf("invalid XML: %s", err)
+		}
+	}
+}
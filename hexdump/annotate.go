@@ -0,0 +1,216 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package hexdump
+
+import (
+	"image/color"
+	"strings"
+
+	"github.com/markkurossi/text"
+	cs "github.com/markkurossi/text/color"
+)
+
+// Annotation defines a labeled byte range. The range covers the dump
+// offsets from From up to, but not including, To.
+type Annotation struct {
+	Label string
+	From  uint64
+	To    uint64
+	// Color specifies an optional background color for the range.
+	// The zero value selects the next color from the annotator's
+	// color scheme.
+	Color color.Color
+}
+
+// Annotator renders hexdumps where the annotated byte ranges are
+// highlighted with background colors. The dump layout is specified
+// with the embedded Dumper.
+type Annotator struct {
+	Dumper
+	// Scheme specifies a qualitative color scheme for the
+	// annotations without an explicit color. The nil value uses the
+	// color.Bright scheme.
+	Scheme *cs.Scheme
+	// Legend specifies if the legend of annotation labels is
+	// rendered after the dump.
+	Legend bool
+}
+
+// NewAnnotator creates a new annotator with the default dump layout,
+// the Bright color scheme, and the legend enabled.
+func NewAnnotator() *Annotator {
+	return &Annotator{
+		Scheme: cs.Bright,
+		Legend: true,
+	}
+}
+
+// ANSI renders the annotated hexdump of data with 24-bit terminal
+// colors.
+func (a *Annotator) ANSI(data []byte, annotations []Annotation) string {
+	return a.Text(data, annotations).ANSI()
+}
+
+// HTML renders the annotated hexdump of data as a HTML pre element.
+func (a *Annotator) HTML(data []byte, annotations []Annotation) string {
+	return "<pre>" + a.Text(data, annotations).HTML() + "</pre>"
+}
+
+// Text renders the annotated hexdump of data. The digits and the
+// text pane characters of the annotated bytes are highlighted with
+// the annotation colors. The separators between the bytes of the
+// same annotation are highlighted too, so each range renders as a
+// continuous band. If the annotations overlap, the later annotation
+// takes precedence.
+func (a *Annotator) Text(data []byte, annotations []Annotation) *text.Text {
+	colors := a.colors(annotations)
+	result := text.New()
+
+	perLine := a.bytesPerLine()
+	cells := make([]cell, perLine)
+	owners := make([]int, perLine)
+	var sb strings.Builder
+
+	offset := a.Offset
+	for len(data) > 0 {
+		l := perLine
+		if l > len(data) {
+			l = len(data)
+		}
+		sb.Reset()
+		a.line(&sb, offset, data[:l], cells)
+		for i := 0; i < l; i++ {
+			owners[i] = owner(annotations, offset+uint64(i))
+		}
+		highlight(result, sb.String(), cells[:l], owners[:l], colors)
+
+		offset += uint64(l)
+		data = data[l:]
+	}
+
+	if a.Legend && len(annotations) > 0 {
+		result.Plain("\n")
+		for idx, ann := range annotations {
+			c := colors[idx]
+			result.AppendSpan(text.Span{
+				FG:      c.FG,
+				BG:      c.BG,
+				Content: "  ",
+			})
+			if ann.To > ann.From {
+				result.Plainf(" %0*x-%0*x %s\n",
+					a.offsetWidth(), ann.From, a.offsetWidth(), ann.To-1,
+					ann.Label)
+			} else {
+				result.Plainf(" %s\n", ann.Label)
+			}
+		}
+	}
+
+	return result
+}
+
+// colors resolves the foreground and background colors of the
+// annotations.
+func (a *Annotator) colors(annotations []Annotation) []cs.Color {
+	scheme := a.Scheme
+	if scheme == nil || len(scheme.Colors) == 0 {
+		scheme = cs.Bright
+	}
+	var result []cs.Color
+	var next int
+	for _, ann := range annotations {
+		if ann.Color != nil {
+			result = append(result, contrast(ann.Color))
+			continue
+		}
+		result = append(result, *scheme.Colors[next%len(scheme.Colors)])
+		next++
+	}
+	return result
+}
+
+// contrast returns a color with the background bg and a black or
+// white foreground, whichever has better contrast with the
+// background.
+func contrast(bg color.Color) cs.Color {
+	result := cs.Color{
+		FG: cs.Black,
+		BG: text.NRGBA(bg),
+	}
+	if cs.Luminance(result.BG) < 128 {
+		result.FG = cs.White
+	}
+	return result
+}
+
+// owner returns the index of the annotation covering offset, or -1
+// if offset is not annotated.
+func owner(annotations []Annotation, offset uint64) int {
+	result := -1
+	for idx, ann := range annotations {
+		if ann.From <= offset && offset < ann.To {
+			result = idx
+		}
+	}
+	return result
+}
+
+// highlight appends the formatted line into result, splitting it
+// into spans by the owners of the data bytes. The owners are indices
+// to colors, or -1 for bytes that are not highlighted. The group
+// separators between the digits of the same owner are highlighted
+// too.
+func highlight(result *text.Text, line string, cells []cell, owners []int,
+	colors []cs.Color) {
+
+	pos := make([]int, len(line))
+	for i := range pos {
+		pos[i] = -1
+	}
+	for i, c := range cells {
+		o := owners[i]
+		if o < 0 {
+			continue
+		}
+		for j := c.hex; j < c.hex+c.hexLen; j++ {
+			pos[j] = o
+		}
+		for j := c.ascii; j >= 0 && j < c.ascii+c.asciiLen; j++ {
+			pos[j] = o
+		}
+	}
+	for i := 0; i < len(line); {
+		o := pos[i]
+		j := i + 1
+		for j < len(line) && pos[j] < 0 && line[j] == ' ' {
+			j++
+		}
+		if o >= 0 && j > i+1 && j-i <= 3 && j < len(line) && pos[j] == o {
+			for k := i + 1; k < j; k++ {
+				pos[k] = o
+			}
+		}
+		i = j
+	}
+
+	start := 0
+	for i := 1; i <= len(line); i++ {
+		if i < len(line) && pos[i] == pos[start] {
+			continue
+		}
+		span := text.Span{
+			Content: line[start:i],
+		}
+		if o := pos[start]; o >= 0 {
+			span.FG = colors[o].FG
+			span.BG = colors[o].BG
+		}
+		result.AppendSpan(span)
+		start = i
+	}
+}
@@ -0,0 +1,167 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package hexdump
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/markkurossi/text"
+	cs "github.com/markkurossi/text/color"
+)
+
+var annotateData = []byte("MAGI\x00\x00\x00\x10hello world")
+
+var annotations = []Annotation{
+	{
+		Label: "magic",
+		From:  0,
+		To:    4,
+	},
+	{
+		Label: "length",
+		From:  4,
+		To:    8,
+	},
+	{
+		Label: "body",
+		From:  8,
+		To:    19,
+	},
+}
+
+func TestAnnotatorText(t *testing.T) {
+	a := NewAnnotator()
+	a.BytesPerLine = 8
+
+	result := a.Text(annotateData, annotations)
+	expected := a.Dump(annotateData) + `
+   00000000-00000003 magic
+   00000004-00000007 length
+   00000008-00000012 body
+`
+	if result.String() != expected {
+		t.Errorf("Text: got\n%s\nexpected\n%s", result, expected)
+	}
+
+	colored := map[string]color.Color{
+		"4d 41 47 49":              cs.Bright.Colors[0].BG,
+		"00 00 00 10":              cs.Bright.Colors[1].BG,
+		"MAGI":                     cs.Bright.Colors[0].BG,
+		"68 65 6c 6c  6f 20 77 6f": cs.Bright.Colors[2].BG,
+		"rld":                      cs.Bright.Colors[2].BG,
+	}
+	for _, span := range result.Spans {
+		bg, ok := colored[span.Content]
+		if !ok {
+			continue
+		}
+		if span.BG != bg {
+			t.Errorf("span %q: BG=%v, expected %v", span.Content, span.BG, bg)
+		}
+		delete(colored, span.Content)
+	}
+	for content := range colored {
+		t.Errorf("span %q not found", content)
+	}
+}
+
+func TestAnnotatorOverlap(t *testing.T) {
+	a := &Annotator{
+		Dumper: Dumper{
+			Offset: 0x100,
+		},
+	}
+	dark := color.NRGBA{
+		R: 0x20,
+		A: 0xff,
+	}
+	result := a.Text([]byte("abcdef"), []Annotation{
+		{
+			Label: "all",
+			From:  0x100,
+			To:    0x106,
+		},
+		{
+			Label: "inner",
+			From:  0x102,
+			To:    0x104,
+			Color: dark,
+		},
+	})
+
+	expected := []text.Span{
+		{Content: "00000100  "},
+		{Content: "61 62", BG: cs.Bright.Colors[0].BG},
+		{Content: " "},
+		{Content: "63 64", BG: dark, FG: cs.White},
+		{Content: " "},
+		{Content: "65 66", BG: cs.Bright.Colors[0].BG},
+	}
+	if len(result.Spans) < len(expected) {
+		t.Fatalf("too few spans: %v", result.Spans)
+	}
+	for i, e := range expected {
+		span := result.Spans[i]
+		if span.Content != e.Content || span.BG != e.BG ||
+			(e.FG != nil && span.FG != e.FG) {
+			t.Errorf("span %d: got %q %v %v, expected %q %v %v", i,
+				span.Content, span.FG, span.BG, e.Content, e.FG, e.BG)
+		}
+	}
+	if strings.Contains(result.String(), "inner") {
+		t.Errorf("legend rendered without Legend option")
+	}
+}
+
+func TestAnnotatorHTML(t *testing.T) {
+	a := NewAnnotator()
+	html := a.HTML([]byte("<&>"), []Annotation{
+		{
+			Label: "x<y",
+			From:  0,
+			To:    3,
+		},
+	})
+	for _, s := range []string{
+		"<pre>",
+		"</pre>",
+		`<span style="color:#000000;background-color:#4477aa">3c 26 3e</span>`,
+		`<span style="color:#000000;background-color:#4477aa">&lt;&amp;&gt;</span>`,
+		"x&lt;y",
+	} {
+		if !strings.Contains(html, s) {
+			t.Errorf("HTML does not contain %q:\n%s", s, html)
+		}
+	}
+}
+
+func TestAnnotatorWords(t *testing.T) {
+	a := &Annotator{
+		Dumper: Dumper{
+			Group:        4,
+			LittleEndian: true,
+		},
+	}
+	result := a.Text([]byte("Hello, world"), []Annotation{
+		{
+			Label: "el",
+			From:  1,
+			To:    3,
+		},
+	})
+	var highlighted []string
+	for _, span := range result.Spans {
+		if span.BG != nil {
+			highlighted = append(highlighted, span.Content)
+		}
+	}
+	if strings.Join(highlighted, ",") != "6c65,el" {
+		t.Errorf("highlighted spans: %q", highlighted)
+	}
+}
@@ -0,0 +1,70 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package hexdump
+
+import (
+	"strings"
+	"testing"
+)
+
+var charsetTests = []struct {
+	charset Charset
+	input   string
+	output  string
+}{
+	{
+		charset: CharsetASCII,
+		input:   "a\x00\x7f\xe9 ",
+		output:  "a... ",
+	},
+	{
+		charset: CharsetLatin1,
+		input:   "a\x00\x85\xa0\xad\xe9",
+		output:  "a....é",
+	},
+	{
+		charset: CharsetEBCDIC,
+		input:   "\xc8\x85\x93\x93\x96\x40\x4b\x25\x00",
+		output:  "Hello ...",
+	},
+	{
+		charset: CharsetUTF16LE,
+		input:   "H\x00i\x00\x00\x00\x3d\xd8\x00\xde!",
+		output:  "Hi.😀.",
+	},
+	{
+		charset: CharsetUTF16LE,
+		input:   "\x3d\xd8A\x00",
+		output:  ".A",
+	},
+}
+
+func TestCharsets(t *testing.T) {
+	for idx, test := range charsetTests {
+		var sb strings.Builder
+		cells := make([]cell, len(test.input))
+		test.charset.pane(&sb, []byte(test.input), cells)
+		if sb.String() != test.output {
+			t.Errorf("test %d: %s: got %q, expected %q", idx, test.charset,
+				sb.String(), test.output)
+		}
+		for i, c := range cells {
+			if c.ascii < 0 || c.ascii+c.asciiLen > sb.Len() {
+				t.Errorf("test %d: invalid cell %d: %v", idx, i, c)
+			}
+		}
+	}
+}
+
+func TestCharsetString(t *testing.T) {
+	if CharsetUTF16LE.String() != "utf16le" {
+		t.Errorf("String: got %q", CharsetUTF16LE)
+	}
+	if Charset(42).String() != "{Charset 42}" {
+		t.Errorf("String: got %q", Charset(42))
+	}
+}
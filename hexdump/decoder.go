@@ -0,0 +1,357 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package hexdump
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var errInvalid = errors.New("invalid hexdump data")
+
+// Decoder decodes hexdump data from an input stream. The input is
+// processed one line at a time so the memory use does not depend on
+// the size of the dump.
+type Decoder struct {
+	r        *bufio.Reader
+	gaps     GapMode
+	strict   bool
+	verify   bool
+	little   bool
+	base     int
+	format   Format
+	parse    lineParser
+	line     []byte
+	out      []byte
+	groups   []int
+	buf      []byte
+	prev     []byte
+	pattern  []byte
+	rep      uint64
+	rpos     int
+	zeros    uint64
+	repeat   bool
+	started  bool
+	next     uint64
+	offset   uint64
+	bufOfs   uint64
+	lineNum  int
+	lineOfs  uint64
+	dataLine int
+	repLine  int
+	input    bool
+	data     bool
+	err      error
+}
+
+// NewDecoder creates a new decoder that reads hexdump data from r.
+// The dump format is detected from the first data line.
+func NewDecoder(r io.Reader) *Decoder {
+	return NewParser().Decoder(r)
+}
+
+// NewFormatDecoder creates a new decoder that reads hexdump data in
+// the argument format from r. If format is FormatAuto, the format is
+// detected from the first data line.
+func NewFormatDecoder(r io.Reader, format Format) *Decoder {
+	p := &Parser{
+		Format: format,
+	}
+	return p.Decoder(r)
+}
+
+// Decoder creates a new decoder that reads hexdump data from r
+// according to the parser options.
+func (p *Parser) Decoder(r io.Reader) *Decoder {
+	d := &Decoder{
+		r:      bufio.NewReader(r),
+		gaps:   p.Gaps,
+		strict: p.Strict,
+		verify: p.VerifyASCII,
+		little: p.LittleEndian,
+		format: p.Format,
+	}
+	switch p.Base {
+	case 0:
+		d.base = 16
+	case 2, 8, 16:
+		d.base = p.Base
+	default:
+		d.err = fmt.Errorf("unsupported base %d", p.Base)
+		return d
+	}
+	if p.Format != FormatAuto {
+		d.parse, d.err = formatParser(p.Format, d.base)
+	}
+	return d
+}
+
+// Format returns the dump format. For auto-detecting decoders, the
+// format is FormatAuto until the first data line is read.
+func (d *Decoder) Format() Format {
+	return d.format
+}
+
+// Offset returns the dump offset following the last byte returned
+// by Read. Since Read never returns bytes across an offset
+// discontinuity, the bytes of the last Read start at Offset minus
+// the number of bytes read.
+func (d *Decoder) Offset() uint64 {
+	return d.offset
+}
+
+// Read implements the io.Reader interface. It returns an error if
+// the input is not empty but it does not contain any data lines, or
+// if the line offsets are invalid for the gap mode.
+func (d *Decoder) Read(p []byte) (int, error) {
+	for {
+		switch {
+		case d.rep > 0:
+			var n int
+			for n < len(p) && d.rep > 0 {
+				c := copy(p[n:], d.pattern[d.rpos:])
+				if uint64(c) > d.rep {
+					c = int(d.rep)
+				}
+				n += c
+				d.rep -= uint64(c)
+				d.rpos = (d.rpos + c) % len(d.pattern)
+			}
+			d.offset += uint64(n)
+			return n, nil
+
+		case d.zeros > 0:
+			n := len(p)
+			if uint64(n) > d.zeros {
+				n = int(d.zeros)
+			}
+			for i := 0; i < n; i++ {
+				p[i] = 0
+			}
+			d.zeros -= uint64(n)
+			d.offset += uint64(n)
+			return n, nil
+
+		case len(d.buf) > 0:
+			n := copy(p, d.buf)
+			d.buf = d.buf[n:]
+			d.bufOfs += uint64(n)
+			d.offset = d.bufOfs
+			return n, nil
+
+		case d.err != nil:
+			return 0, d.err
+
+		default:
+			d.fill()
+		}
+	}
+}
+
+// fill decodes the next input line into the output buffer.
+func (d *Decoder) fill() {
+	line, err := d.readLine()
+	if len(line) > 0 {
+		d.input = true
+		d.lineNum++
+	}
+	if len(line) > 0 && line[len(line)-1] == '\n' {
+		line = line[:len(line)-1]
+	}
+	if len(line) > 0 && line[len(line)-1] == '\r' {
+		line = line[:len(line)-1]
+	}
+
+	if lerr := d.decodeLine(line); lerr != nil {
+		d.err = lerr
+		return
+	}
+	if err == io.EOF {
+		if d.repeat {
+			err = d.errorf(d.repLine, 1, "repeat marker without end offset")
+		} else if d.input && !d.data {
+			err = errInvalid
+		}
+	}
+	d.err = err
+}
+
+// decodeLine decodes the line and schedules its data, and the
+// repeated and zero-filled bytes preceding it, for reading.
+func (d *Decoder) decodeLine(line []byte) error {
+	if d.parse == nil {
+		format, ok := detectLine(line)
+		if !ok {
+			return nil
+		}
+		d.format = format
+		parse, err := formatParser(format, d.base)
+		if err != nil {
+			return err
+		}
+		d.parse = parse
+	}
+	if skipBlanks(line, 0) == len(line) {
+		return nil
+	}
+	if isRepeat(line) {
+		if !d.started {
+			if d.strict {
+				return d.errorf(d.lineNum, 1, "repeat marker without data")
+			}
+			return nil
+		}
+		d.repeat = true
+		d.repLine = d.lineNum
+		return nil
+	}
+
+	info := lineInfo{
+		groups: d.groups[:0],
+	}
+	d.out = d.parse(d.out[:0], line, &info)
+	d.groups = info.groups
+	if d.little {
+		var start int
+		for _, end := range info.groups {
+			reverse(d.out[start:end])
+			start = end
+		}
+	}
+	if d.strict {
+		if info.kind == lineInvalid {
+			return d.errorf(d.lineNum, 1, "invalid %s line", d.format)
+		}
+		if info.col != 0 {
+			return d.errorf(d.lineNum, info.col, "%s", info.msg)
+		}
+	}
+	if info.kind != lineData {
+		return nil
+	}
+	if len(d.out) == 0 && info.col != 0 {
+		// Data lines without any valid groups are errors also in
+		// the lenient mode since skipping them would decode the dump
+		// partially.
+		return d.errorf(d.lineNum, info.col, "%s", info.msg)
+	}
+	if d.verify && info.pane != nil {
+		if err := d.verifyPane(&info); err != nil {
+			return err
+		}
+	}
+	offset := info.offset
+
+	if !d.started {
+		d.started = true
+		d.next = offset
+		d.offset = offset
+	}
+	if d.repeat {
+		d.repeat = false
+		if offset > d.next && len(d.prev) > 0 {
+			d.pattern = append(d.pattern[:0], d.prev...)
+			d.rep = offset - d.next
+			d.rpos = 0
+			d.next = offset
+		}
+	}
+
+	switch {
+	case offset == d.next:
+	case len(d.out) == 0 && offset < d.next:
+		// The end offset of a word dump does not count the padding
+		// byte of an odd length input.
+		return nil
+	case d.gaps == GapIgnore:
+	case offset < d.next && d.dataLine > 0 && offset > d.lineOfs:
+		return d.errorf(d.dataLine, 1,
+			"%d bytes decoded for offsets %x-%x: groups do not match base %d",
+			d.next-d.lineOfs, d.lineOfs, offset-1, d.base)
+	case offset < d.next:
+		return d.errorf(d.lineNum, 1, "offset %x overlaps data ending at %x",
+			offset, d.next)
+	case d.gaps == GapFill:
+		d.zeros = offset - d.next
+	default:
+		return d.errorf(d.lineNum, 1, "gap between offsets %x and %x",
+			d.next, offset)
+	}
+
+	d.next = offset + uint64(len(d.out))
+	d.buf = d.out
+	d.bufOfs = offset
+	if len(d.out) > 0 {
+		d.lineOfs = offset
+		d.dataLine = d.lineNum
+		d.data = true
+		d.prev = append(d.prev[:0], d.out...)
+	}
+	return nil
+}
+
+func reverse(data []byte) {
+	for i, j := 0, len(data)-1; i < j; i, j = i+1, j-1 {
+		data[i], data[j] = data[j], data[i]
+	}
+}
+
+// verifyPane verifies that the ASCII pane matches the decoded line
+// data. The printable ASCII bytes must match themselves and all other
+// bytes must be shown as '.'.
+func (d *Decoder) verifyPane(info *lineInfo) error {
+	pane := info.pane
+	col := info.paneCol + 1
+
+	for i, b := range d.out {
+		if i >= len(pane) {
+			return d.errorf(d.lineNum, col+len(pane),
+				"ASCII pane too short: %d bytes for %d data bytes",
+				len(pane), len(d.out))
+		}
+		ch := b
+		if b < 32 || b > 126 {
+			ch = '.'
+		}
+		if pane[i] != ch {
+			return d.errorf(d.lineNum, col+i,
+				"ASCII pane %q does not match data byte %02x", pane[i], b)
+		}
+	}
+	if len(pane) > len(d.out) {
+		return d.errorf(d.lineNum, col+len(d.out),
+			"ASCII pane too long: %d bytes for %d data bytes",
+			len(pane), len(d.out))
+	}
+	return nil
+}
+
+func (d *Decoder) errorf(line, col int, format string,
+	a ...interface{}) error {
+	return &ParseError{
+		Line:   line,
+		Column: col,
+		Msg:    fmt.Sprintf(format, a...),
+	}
+}
+
+// readLine reads the next line, including the newline. Lines longer
+// than the input buffer are collected into the line buffer.
+func (d *Decoder) readLine() ([]byte, error) {
+	line, err := d.r.ReadSlice('\n')
+	if err != bufio.ErrBufferFull {
+		return line, err
+	}
+	d.line = append(d.line[:0], line...)
+	for err == bufio.ErrBufferFull {
+		line, err = d.r.ReadSlice('\n')
+		d.line = append(d.line, line...)
+	}
+	return d.line, err
+}
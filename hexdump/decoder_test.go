@@ -0,0 +1,175 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package hexdump
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestDecoder(t *testing.T) {
+	for _, test := range corpusTests {
+		expected, err := os.ReadFile(filepath.Join("testdata", test.bin))
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := os.ReadFile(filepath.Join("testdata", test.file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		d := NewDecoder(iotest.OneByteReader(bytes.NewReader(data)))
+		if d.Format() != FormatAuto {
+			t.Errorf("%s: Format=%v before read", test.file, d.Format())
+		}
+		result, err := io.ReadAll(d)
+		if err != nil {
+			t.Errorf("%s: Read failed: %v", test.file, err)
+			continue
+		}
+		if !bytes.Equal(result, expected) {
+			t.Errorf("%s: got\n%sexpected\n%s", test.file,
+				hex.Dump(result), hex.Dump(expected))
+		}
+		if d.Format() != test.format {
+			t.Errorf("%s: Format=%v, expected %v", test.file, d.Format(),
+				test.format)
+		}
+	}
+}
+
+func TestDecoderLongLines(t *testing.T) {
+	data := make([]byte, 10000)
+	rand.New(rand.NewSource(1)).Read(data)
+
+	dumper := &Dumper{
+		BytesPerLine: 4096,
+	}
+	result, err := io.ReadAll(NewDecoder(strings.NewReader(dumper.Dump(data))))
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if !bytes.Equal(result, data) {
+		t.Errorf("long lines decoded incorrectly")
+	}
+}
+
+func TestDecoderErrors(t *testing.T) {
+	result, err := io.ReadAll(NewDecoder(strings.NewReader("")))
+	if err != nil || len(result) != 0 {
+		t.Errorf("empty input: %v %v", result, err)
+	}
+	_, err = io.ReadAll(NewDecoder(strings.NewReader("hello, world\n")))
+	if err != errInvalid {
+		t.Errorf("invalid input: got %v, expected %v", err, errInvalid)
+	}
+	_, err = io.ReadAll(NewFormatDecoder(strings.NewReader("0000  61\n"),
+		Format(100)))
+	if err == nil {
+		t.Errorf("unknown format succeeded")
+	}
+
+	readErr := errors.New("read error")
+	_, err = io.ReadAll(NewDecoder(io.MultiReader(
+		strings.NewReader("0000  6162\n"), iotest.ErrReader(readErr))))
+	if err != readErr {
+		t.Errorf("read error: got %v, expected %v", err, readErr)
+	}
+}
+
+// The regular expression based parser that preceded the Decoder. It
+// is kept as the baseline for the benchmarks.
+var (
+	reLine = regexp.MustCompilePOSIX(
+		`^[[:xdigit:]]{7,8}(([[:blank:]]+[[:xdigit:]]+){1,16}).*$`)
+	reByte = regexp.MustCompilePOSIX(`([[:xdigit:]]{2})`)
+)
+
+func parseRegexp(data []byte) ([]byte, error) {
+	var result bytes.Buffer
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	for {
+		match := reLine.FindSubmatchIndex(data)
+		if match == nil {
+			if result.Len() == 0 {
+				return nil, errInvalid
+			}
+			return result.Bytes(), nil
+		}
+		bytes := data[match[2]:match[3]]
+		data = data[match[1]:]
+
+		for {
+			m := reByte.FindSubmatchIndex(bytes)
+			if m == nil {
+				break
+			}
+			result.WriteByte(hex2bin(bytes[m[2]])<<4 | hex2bin(bytes[m[2]+1]))
+			bytes = bytes[m[1]:]
+		}
+	}
+}
+
+func TestParseRegexp(t *testing.T) {
+	dump := []byte(hex.Dump(benchmarkData()))
+	expected, err := parseRegexp(dump)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := Parse(dump)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(result, expected) {
+		t.Errorf("Parse and regexp parser results differ")
+	}
+}
+
+func benchmarkData() []byte {
+	data := make([]byte, 1<<20)
+	rand.New(rand.NewSource(1)).Read(data)
+	return data
+}
+
+func BenchmarkParseRegexp(b *testing.B) {
+	dump := []byte(hex.Dump(benchmarkData()))
+	b.SetBytes(int64(len(dump)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		parseRegexp(dump)
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	dump := []byte(hex.Dump(benchmarkData()))
+	b.SetBytes(int64(len(dump)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Parse(dump)
+	}
+}
+
+func BenchmarkDecoder(b *testing.B) {
+	dump := []byte(hex.Dump(benchmarkData()))
+	b.SetBytes(int64(len(dump)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		io.Copy(io.Discard, NewDecoder(bytes.NewReader(dump)))
+	}
+}
@@ -0,0 +1,500 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package hexdump
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/markkurossi/text"
+	cs "github.com/markkurossi/text/color"
+)
+
+// DiffLayout defines the layouts of the diff output.
+type DiffLayout int
+
+// Diff layouts.
+const (
+	// DiffSideBySide renders the two inputs side by side.
+	DiffSideBySide DiffLayout = iota
+	// DiffInterleaved renders the differing lines of the two inputs
+	// on consecutive lines, prefixed with '-' and '+'.
+	DiffInterleaved
+)
+
+var diffLayouts = map[DiffLayout]string{
+	DiffSideBySide:  "side-by-side",
+	DiffInterleaved: "interleaved",
+}
+
+func (l DiffLayout) String() string {
+	name, ok := diffLayouts[l]
+	if ok {
+		return name
+	}
+	return fmt.Sprintf("{DiffLayout %d}", l)
+}
+
+// Differ renders the differences of two inputs as hexdumps. The dump
+// layout is specified with the embedded Dumper.
+type Differ struct {
+	Dumper
+	// Layout specifies the output layout.
+	Layout DiffLayout
+	// Insertions enables the insertion-aware alignment. By default,
+	// the inputs are compared byte by byte at the same positions. With
+	// insertions, the inputs are aligned with a byte-level diff so
+	// that the inserted and deleted bytes do not shift the rest of
+	// the inputs out of alignment.
+	Insertions bool
+	// MaxEdits limits the number of edit steps searched in the
+	// insertion-aware alignment. When the limit is reached, the bytes
+	// of the remaining differing regions are compared by positions.
+	// The limit bounds the running time to about MaxEdits times the
+	// input size. The zero value uses 1000 steps.
+	MaxEdits int
+	// Context specifies the number of identical lines shown before
+	// and after the differing lines. The longer runs of identical
+	// lines are collapsed into a single marker line.
+	Context int
+	// Removed specifies the highlight color for the differing bytes
+	// of the first input. The nil value uses the red color of the
+	// Bright color scheme.
+	Removed color.Color
+	// Added specifies the highlight color for the differing bytes of
+	// the second input. The nil value uses the green color of the
+	// Bright color scheme.
+	Added color.Color
+}
+
+// NewDiffer creates a new differ with the default dump layout, the
+// side-by-side output layout, and one line of context.
+func NewDiffer() *Differ {
+	return &Differ{
+		Context: 1,
+	}
+}
+
+// Diff renders the differences of a and b with the default differ.
+func Diff(a, b []byte) *text.Text {
+	return NewDiffer().Diff(a, b)
+}
+
+// pair defines an aligned pair of input indices. The index is -1 if
+// the byte is missing from the input.
+type pair struct {
+	a, b int
+}
+
+// diffRow defines the rendering of one aligned row of the inputs.
+type diffRow struct {
+	same   bool
+	lines  [2]string
+	cells  [2][]cell
+	owners [2][]int
+}
+
+// Diff renders the differences of a and b. The differing bytes are
+// highlighted with the Removed and Added colors.
+func (d *Differ) Diff(a, b []byte) *text.Text {
+	var pairs []pair
+	if d.Insertions {
+		maxEdits := d.MaxEdits
+		if maxEdits == 0 {
+			maxEdits = 1000
+		}
+		pairs = compact(align(a, b, maxEdits))
+	} else {
+		for i := 0; i < len(a) || i < len(b); i++ {
+			p := pair{
+				a: i,
+				b: i,
+			}
+			if i >= len(a) {
+				p.a = -1
+			}
+			if i >= len(b) {
+				p.b = -1
+			}
+			pairs = append(pairs, p)
+		}
+	}
+
+	var rows []diffRow
+	perLine := d.bytesPerLine()
+	for len(pairs) > 0 {
+		l := perLine
+		if l > len(pairs) {
+			l = len(pairs)
+		}
+		rows = append(rows, d.row(a, b, pairs[:l]))
+		pairs = pairs[l:]
+	}
+
+	removed := d.Removed
+	if removed == nil {
+		removed = cs.Bright.Colors[4].BG
+	}
+	added := d.Added
+	if added == nil {
+		added = cs.Bright.Colors[2].BG
+	}
+	colors := [2][]cs.Color{
+		{contrast(removed)},
+		{contrast(added)},
+	}
+
+	var width int
+	for _, row := range rows {
+		if w := text.StringWidth(row.lines[0]); w > width {
+			width = w
+		}
+	}
+
+	result := text.New()
+	for i := 0; i < len(rows); {
+		if !rows[i].same {
+			d.render(result, &rows[i], width, colors)
+			i++
+			continue
+		}
+		end := i
+		for end < len(rows) && rows[end].same {
+			end++
+		}
+		head := d.Context
+		if i == 0 {
+			head = 0
+		}
+		tail := d.Context
+		if end == len(rows) {
+			tail = 0
+		}
+		if head < 0 {
+			head = 0
+		}
+		if tail < 0 {
+			tail = 0
+		}
+		if end-i <= head+tail+1 {
+			head = end - i
+			tail = 0
+		}
+		for j := i; j < i+head; j++ {
+			d.render(result, &rows[j], width, colors)
+		}
+		if n := end - i - head - tail; n > 0 {
+			result.Plainf("…%d identical lines…\n", n)
+		}
+		for j := end - tail; j < end; j++ {
+			d.render(result, &rows[j], width, colors)
+		}
+		i = end
+	}
+	return result
+}
+
+// row formats the aligned row of pairs.
+func (d *Differ) row(a, b []byte, pairs []pair) diffRow {
+	row := diffRow{
+		same: true,
+	}
+	for _, p := range pairs {
+		if p.a < 0 || p.b < 0 || a[p.a] != b[p.b] {
+			row.same = false
+			break
+		}
+	}
+	inputs := [2][]byte{a, b}
+	for side := 0; side < 2; side++ {
+		row.lines[side], row.cells[side], row.owners[side] =
+			d.half(inputs[side], inputs[1-side], pairs, side)
+	}
+	return row
+}
+
+// half formats one side of a row. It returns an empty line if the
+// side does not have any bytes in the row. If the first column of
+// the row is a missing byte, the offset is shown as dashes since
+// the column does not have an offset in the input.
+func (d *Differ) half(data, other []byte, pairs []pair,
+	side int) (string, []cell, []int) {
+
+	index := func(p pair, side int) int {
+		if side == 0 {
+			return p.a
+		}
+		return p.b
+	}
+
+	n := len(pairs)
+	for n > 0 && index(pairs[n-1], side) < 0 {
+		n--
+	}
+	if n == 0 {
+		return "", nil, nil
+	}
+	var offset uint64
+	vals := make([]byte, n)
+	owners := make([]int, n)
+	for i := n - 1; i >= 0; i-- {
+		owners[i] = -1
+		idx := index(pairs[i], side)
+		if idx < 0 {
+			continue
+		}
+		offset = d.Offset + uint64(idx)
+		vals[i] = data[idx]
+		oidx := index(pairs[i], 1-side)
+		if oidx < 0 || other[oidx] != vals[i] {
+			owners[i] = 0
+		}
+	}
+
+	var sb strings.Builder
+	cells := make([]cell, n)
+	d.line(&sb, offset, vals, cells)
+	line := []byte(strings.TrimSuffix(sb.String(), "\n"))
+	if index(pairs[0], side) < 0 {
+		for i := 0; i < len(line) && line[i] != ' '; i++ {
+			line[i] = '-'
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		if index(pairs[i], side) >= 0 {
+			continue
+		}
+		c := cells[i]
+		for j := c.hex; j < c.hex+c.hexLen; j++ {
+			line[j] = ' '
+		}
+		for j := c.ascii; j >= 0 && j < c.ascii+c.asciiLen; j++ {
+			line[j] = ' '
+		}
+	}
+	return string(line), cells, owners
+}
+
+// render renders the row into result.
+func (d *Differ) render(result *text.Text, row *diffRow, width int,
+	colors [2][]cs.Color) {
+
+	if d.Layout == DiffInterleaved {
+		if row.same {
+			result.Plain("  " + row.lines[0] + "\n")
+			return
+		}
+		for side, prefix := range []string{"- ", "+ "} {
+			if len(row.lines[side]) == 0 {
+				continue
+			}
+			result.Plain(prefix)
+			highlight(result, row.lines[side], row.cells[side],
+				row.owners[side], colors[side])
+			result.Plain("\n")
+		}
+		return
+	}
+
+	highlight(result, row.lines[0], row.cells[0], row.owners[0], colors[0])
+	if len(row.lines[1]) > 0 {
+		result.Plain(strings.Repeat(" ", width-text.StringWidth(row.lines[0])+2))
+		highlight(result, row.lines[1], row.cells[1], row.owners[1],
+			colors[1])
+	}
+	result.Plain("\n")
+}
+
+// compact pairs the deleted and inserted bytes of each differing
+// region so that they are rendered in the same columns.
+func compact(pairs []pair) []pair {
+	var result []pair
+	for i := 0; i < len(pairs); {
+		if pairs[i].a >= 0 && pairs[i].b >= 0 {
+			result = append(result, pairs[i])
+			i++
+			continue
+		}
+		var deleted, inserted []int
+		for ; i < len(pairs) && (pairs[i].a < 0 || pairs[i].b < 0); i++ {
+			if pairs[i].a >= 0 {
+				deleted = append(deleted, pairs[i].a)
+			} else {
+				inserted = append(inserted, pairs[i].b)
+			}
+		}
+		for j := 0; j < len(deleted) || j < len(inserted); j++ {
+			p := pair{
+				a: -1,
+				b: -1,
+			}
+			if j < len(deleted) {
+				p.a = deleted[j]
+			}
+			if j < len(inserted) {
+				p.b = inserted[j]
+			}
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// align aligns a and b with a shortest edit script. The equal bytes
+// are paired and the deleted and inserted bytes are paired with
+// missing bytes. The edit script search is limited to maxEdits edit
+// steps in total. When the limit is reached, the rest of each
+// differing region is not searched but all its bytes are deleted
+// and inserted, so the compact function pairs them by positions.
+func align(a, b []byte, maxEdits int) []pair {
+	al := &aligner{
+		budget: maxEdits,
+	}
+	al.alignRange(a, b, 0, 0)
+	return al.result
+}
+
+type aligner struct {
+	budget int
+	result []pair
+}
+
+func (al *aligner) alignRange(a, b []byte, ao, bo int) {
+	var prefix int
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		al.result = append(al.result, pair{ao + prefix, bo + prefix})
+		prefix++
+	}
+	a = a[prefix:]
+	b = b[prefix:]
+	ao += prefix
+	bo += prefix
+
+	var suffix int
+	for suffix < len(a) && suffix < len(b) &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	a = a[:len(a)-suffix]
+	b = b[:len(b)-suffix]
+
+	var x, y, cost int
+	var ok bool
+	if al.budget > 0 {
+		x, y, cost, ok = bisect(a, b, al.budget)
+		al.budget -= cost
+	}
+	if ok {
+		al.alignRange(a[:x], b[:y], ao, bo)
+		al.alignRange(a[x:], b[y:], ao+x, bo+y)
+	} else {
+		for i := range a {
+			al.result = append(al.result, pair{ao + i, -1})
+		}
+		for i := range b {
+			al.result = append(al.result, pair{-1, bo + i})
+		}
+	}
+
+	for i := 0; i < suffix; i++ {
+		al.result = append(al.result, pair{ao + len(a) + i, bo + len(b) + i})
+	}
+}
+
+// bisect finds the middle snake of the shortest edit script of a and
+// b with the linear space variant of the Myers' diff algorithm. The
+// search is limited to limit edit steps. It returns the split point
+// of the inputs and the number of edit steps searched, or false if
+// the inputs do not have any common bytes or if the limit was
+// reached.
+func bisect(a, b []byte, limit int) (int, int, int, bool) {
+	n := len(a)
+	m := len(b)
+	if n == 0 || m == 0 {
+		return 0, 0, 0, false
+	}
+	maxD := (n + m + 1) / 2
+	if maxD > limit {
+		maxD = limit
+	}
+	vOffset := maxD
+	vLength := 2*maxD + 2
+	v1 := make([]int, vLength)
+	v2 := make([]int, vLength)
+	for i := range v1 {
+		v1[i] = -1
+		v2[i] = -1
+	}
+	v1[vOffset+1] = 0
+	v2[vOffset+1] = 0
+
+	delta := n - m
+	front := delta%2 != 0
+	var k1start, k1end, k2start, k2end int
+
+	for d := 0; d < maxD; d++ {
+		for k1 := -d + k1start; k1 <= d-k1end; k1 += 2 {
+			k1Offset := vOffset + k1
+			var x1 int
+			if k1 == -d || (k1 != d && v1[k1Offset-1] < v1[k1Offset+1]) {
+				x1 = v1[k1Offset+1]
+			} else {
+				x1 = v1[k1Offset-1] + 1
+			}
+			y1 := x1 - k1
+			for x1 < n && y1 < m && a[x1] == b[y1] {
+				x1++
+				y1++
+			}
+			v1[k1Offset] = x1
+			if x1 > n {
+				k1end += 2
+			} else if y1 > m {
+				k1start += 2
+			} else if front {
+				k2Offset := vOffset + delta - k1
+				if k2Offset >= 0 && k2Offset < vLength && v2[k2Offset] != -1 {
+					if x1 >= n-v2[k2Offset] {
+						return x1, y1, d + 1, true
+					}
+				}
+			}
+		}
+		for k2 := -d + k2start; k2 <= d-k2end; k2 += 2 {
+			k2Offset := vOffset + k2
+			var x2 int
+			if k2 == -d || (k2 != d && v2[k2Offset-1] < v2[k2Offset+1]) {
+				x2 = v2[k2Offset+1]
+			} else {
+				x2 = v2[k2Offset-1] + 1
+			}
+			y2 := x2 - k2
+			for x2 < n && y2 < m && a[n-x2-1] == b[m-y2-1] {
+				x2++
+				y2++
+			}
+			v2[k2Offset] = x2
+			if x2 > n {
+				k2end += 2
+			} else if y2 > m {
+				k2start += 2
+			} else if !front {
+				k1Offset := vOffset + delta - k2
+				if k1Offset >= 0 && k1Offset < vLength && v1[k1Offset] != -1 {
+					x1 := v1[k1Offset]
+					y1 := x1 - (k1Offset - vOffset)
+					if x1 >= n-x2 {
+						return x1, y1, d + 1, true
+					}
+				}
+			}
+		}
+	}
+	return 0, 0, maxD, false
+}
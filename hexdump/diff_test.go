@@ -0,0 +1,211 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package hexdump
+
+import (
+	"math/rand"
+	"testing"
+
+	cs "github.com/markkurossi/text/color"
+)
+
+func lcs(a, b []byte) int {
+	dp := make([][]int, len(a)+1)
+	for i := range dp {
+		dp[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				dp[i][j] = dp[i+1][j+1] + 1
+			case dp[i+1][j] > dp[i][j+1]:
+				dp[i][j] = dp[i+1][j]
+			default:
+				dp[i][j] = dp[i][j+1]
+			}
+		}
+	}
+	return dp[0][0]
+}
+
+func TestAlign(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for iter := 0; iter < 2000; iter++ {
+		a := make([]byte, rnd.Intn(40))
+		b := make([]byte, rnd.Intn(40))
+		for i := range a {
+			a[i] = byte('a' + rnd.Intn(4))
+		}
+		for i := range b {
+			b[i] = byte('a' + rnd.Intn(4))
+		}
+
+		var ai, bi, equal int
+		for _, p := range align(a, b, 1<<30) {
+			if p.a >= 0 {
+				if p.a != ai {
+					t.Fatalf("align(%q, %q): a index %d, expected %d",
+						a, b, p.a, ai)
+				}
+				ai++
+			}
+			if p.b >= 0 {
+				if p.b != bi {
+					t.Fatalf("align(%q, %q): b index %d, expected %d",
+						a, b, p.b, bi)
+				}
+				bi++
+			}
+			if p.a >= 0 && p.b >= 0 {
+				if a[p.a] != b[p.b] {
+					t.Fatalf("align(%q, %q): unequal pair %v", a, b, p)
+				}
+				equal++
+			}
+		}
+		if ai != len(a) || bi != len(b) {
+			t.Fatalf("align(%q, %q): consumed %d, %d bytes", a, b, ai, bi)
+		}
+		if expected := lcs(a, b); equal != expected {
+			t.Fatalf("align(%q, %q): %d equal bytes, expected %d",
+				a, b, equal, expected)
+		}
+	}
+}
+
+func TestAlignLimit(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	a := make([]byte, 1<<20)
+	b := make([]byte, 1<<20)
+	rnd.Read(a)
+	rnd.Read(b)
+	b[0] = a[0]
+
+	// The random inputs exceed the limit so the bytes after the
+	// common prefix are paired by positions.
+	pairs := compact(align(a, b, 100))
+	if len(pairs) != len(a) {
+		t.Fatalf("align: got %d pairs, expected %d", len(pairs), len(a))
+	}
+	for i, p := range pairs {
+		if p.a != i || p.b != i {
+			t.Fatalf("align: pair %d: got %v", i, p)
+		}
+	}
+}
+
+var diffTests = []struct {
+	d      Differ
+	a, b   string
+	output string
+}{
+	{
+		d: Differ{
+			Dumper: Dumper{
+				BytesPerLine: 4,
+				OffsetWidth:  4,
+				NoASCII:      true,
+			},
+			Context: 1,
+		},
+		a: "aaaabbbbccccddddeeeeffff",
+		b: "aaaabbbbccccddddeeeeffXf!",
+		output: `…4 identical lines…
+0010  65 65  65 65  0010  65 65  65 65
+0014  66 66  66 66  0014  66 66  58 66
+                    0018  21
+`,
+	},
+	{
+		d: Differ{
+			Dumper: Dumper{
+				BytesPerLine: 4,
+				OffsetWidth:  4,
+			},
+			Context: 1,
+		},
+		a: "aaaabbbbccccddddeeeeffff",
+		b: "aaaaXbbbccccddddeeeeffff",
+		output: `0000  61 61  61 61  |aaaa|  0000  61 61  61 61  |aaaa|
+0004  62 62  62 62  |bbbb|  0004  58 62  62 62  |Xbbb|
+0008  63 63  63 63  |cccc|  0008  63 63  63 63  |cccc|
+…3 identical lines…
+`,
+	},
+	{
+		d: Differ{
+			Dumper: Dumper{
+				BytesPerLine: 4,
+				OffsetWidth:  4,
+			},
+			Layout:     DiffInterleaved,
+			Insertions: true,
+		},
+		a: "aaaabbbbccccddddeeee",
+		b: "aaaabbXbbccccddddeeee",
+		output: `  0000  61 61  61 61  |aaaa|
+- 0004  62 62     62  |bb b|
++ 0004  62 62  58 62  |bbXb|
+…4 identical lines…
+`,
+	},
+	{
+		d: Differ{
+			Dumper: Dumper{
+				BytesPerLine: 8,
+				OffsetWidth:  4,
+			},
+			Layout:     DiffInterleaved,
+			Insertions: true,
+			MaxEdits:   1,
+		},
+		a: "abcdefgh",
+		b: "aXbcdeYfgZh",
+		output: `- 0000  61 62 63 64  65 66 67     |abcdefg|
++ 0000  61 58 62 63  64 65 59 66  |aXbcdeYf|
+- ----        68                  |  h|
++ 0008  67 5a 68                  |gZh|
+`,
+	},
+}
+
+func TestDiff(t *testing.T) {
+	for idx, test := range diffTests {
+		result := test.d.Diff([]byte(test.a), []byte(test.b)).String()
+		if result != test.output {
+			t.Errorf("test %d: got\n%s\nexpected\n%s", idx, result, test.output)
+		}
+	}
+}
+
+func TestDiffColors(t *testing.T) {
+	result := Diff([]byte("abc"), []byte("aXc"))
+	var removed, added bool
+	for _, span := range result.Spans {
+		switch span.Content {
+		case "62", "b":
+			removed = true
+			if span.BG != cs.Bright.Colors[4].BG {
+				t.Errorf("removed %q: BG=%v", span.Content, span.BG)
+			}
+		case "58", "X":
+			added = true
+			if span.BG != cs.Bright.Colors[2].BG {
+				t.Errorf("added %q: BG=%v", span.Content, span.BG)
+			}
+		}
+	}
+	if !removed || !added {
+		t.Errorf("differences not highlighted: %v", result.Spans)
+	}
+
+	result = Diff([]byte("identical"), []byte("identical"))
+	if result.String() == "" {
+		t.Errorf("single identical line collapsed")
+	}
+}
@@ -0,0 +1,279 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package hexdump
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Dumper implements a configurable hexdump formatter. The default
+// configuration produces the same output as the encoding/hex.Dump
+// function. The output of all hex configurations can be parsed with
+// the Parse function, and the little-endian, octal, and binary
+// configurations with a Parser with the matching LittleEndian and
+// Base options.
+type Dumper struct {
+	// BytesPerLine specifies the number of bytes per line. The zero
+	// value uses 16 bytes.
+	BytesPerLine int
+	// Group specifies the number of bytes in each group: 1, 2, 4,
+	// or 8. The groups are printed as 8, 16, 32, or 64-bit words.
+	// The zero value uses 1 byte.
+	Group int
+	// LittleEndian specifies if the groups are little-endian words.
+	// By default, the groups are big-endian words and their bytes
+	// are printed in memory order.
+	LittleEndian bool
+	// Base specifies the number base of the groups: 16, 8, or 2. The
+	// zero value uses 16.
+	Base int
+	// OffsetWidth specifies the minimum number of hex digits in the
+	// offset column. The zero value uses 8 digits, values smaller
+	// than 4 are raised to 4, and values larger than 16 are lowered
+	// to 16, the width of 64-bit offsets.
+	OffsetWidth int
+	// Offset specifies the base offset of the first byte.
+	Offset uint64
+	// Uppercase specifies if hex digits are printed in uppercase.
+	Uppercase bool
+	// NoASCII disables the text pane.
+	NoASCII bool
+	// Charset specifies the character set of the text pane.
+	Charset Charset
+}
+
+// NewDumper creates a new dumper with the default configuration.
+func NewDumper() *Dumper {
+	return &Dumper{}
+}
+
+// Dump returns the hexdump of data.
+func (d *Dumper) Dump(data []byte) string {
+	var sb strings.Builder
+	w := d.Writer(&sb)
+	w.Write(data)
+	w.Close()
+	return sb.String()
+}
+
+// Writer returns a writer that writes the hexdump of the written
+// data to w. The writer must be closed to flush the last partial
+// line.
+func (d *Dumper) Writer(w io.Writer) io.WriteCloser {
+	return &dumpWriter{
+		d:      d,
+		w:      w,
+		offset: d.Offset,
+	}
+}
+
+func (d *Dumper) bytesPerLine() int {
+	if d.BytesPerLine <= 0 {
+		return 16
+	}
+	return d.BytesPerLine
+}
+
+func (d *Dumper) group() int {
+	switch d.Group {
+	case 2, 4, 8:
+		return d.Group
+	default:
+		return 1
+	}
+}
+
+func (d *Dumper) base() int {
+	switch d.Base {
+	case 2, 8:
+		return d.Base
+	default:
+		return 16
+	}
+}
+
+func (d *Dumper) offsetWidth() int {
+	switch {
+	case d.OffsetWidth == 0:
+		return 8
+	case d.OffsetWidth < 4:
+		return 4
+	case d.OffsetWidth > 16:
+		return 16
+	default:
+		return d.OffsetWidth
+	}
+}
+
+// cell defines the positions and lengths of a data byte's digits
+// and text pane character in a formatted line. The bytes of octal
+// words share the cell of the whole word, and the bytes of UTF-16
+// code units share their character. The ascii position is -1 if the
+// line does not have the text pane.
+type cell struct {
+	hex      int
+	hexLen   int
+	ascii    int
+	asciiLen int
+}
+
+// line formats one hexdump line for the data at offset. The data
+// must not be longer than BytesPerLine. If cells is not nil, the
+// positions of the data bytes are stored into it.
+func (d *Dumper) line(sb *strings.Builder, offset uint64, data []byte,
+	cells []cell) {
+
+	perLine := d.bytesPerLine()
+	group := d.group()
+	groups := (perLine + group - 1) / group
+	base := d.base()
+
+	if d.Uppercase {
+		fmt.Fprintf(sb, "%0*X  ", d.offsetWidth(), offset)
+	} else {
+		fmt.Fprintf(sb, "%0*x  ", d.offsetWidth(), offset)
+	}
+
+	for g := 0; g < groups; g++ {
+		start := g * group
+		size := group
+		if start+size > perLine {
+			size = perLine - start
+		}
+		var n int
+		if start < len(data) {
+			n = len(data) - start
+			if n > size {
+				n = size
+			}
+		}
+
+		// Partial words are aligned to the side of their lowest
+		// memory address.
+		pad := digits(size, base) - digits(n, base)
+		if n > 0 && d.LittleEndian {
+			sb.WriteString(strings.Repeat(" ", pad))
+		}
+		pos := sb.Len()
+		if n > 0 {
+			d.word(sb, data[start:start+n], base)
+		}
+		if n == 0 || !d.LittleEndian {
+			sb.WriteString(strings.Repeat(" ", pad))
+		}
+
+		if cells != nil {
+			for k := 0; k < n; k++ {
+				c := &cells[start+k]
+				c.ascii = -1
+				if base == 8 && n > 1 {
+					c.hex = pos
+					c.hexLen = digits(n, base)
+					continue
+				}
+				idx := k
+				if d.LittleEndian {
+					idx = n - 1 - k
+				}
+				c.hexLen = digits(1, base)
+				c.hex = pos + idx*c.hexLen
+			}
+		}
+
+		sb.WriteByte(' ')
+		if groups > 1 && groups%2 == 0 && g+1 == groups/2 {
+			sb.WriteByte(' ')
+		}
+	}
+
+	if d.NoASCII {
+		line := strings.TrimRight(sb.String(), " ")
+		sb.Reset()
+		sb.WriteString(line)
+	} else {
+		sb.WriteString(" |")
+		d.Charset.pane(sb, data, cells)
+		sb.WriteByte('|')
+	}
+	sb.WriteByte('\n')
+}
+
+// word writes the data bytes of a group as a number in base.
+func (d *Dumper) word(sb *strings.Builder, data []byte, base int) {
+	var v uint64
+	for i := range data {
+		if d.LittleEndian {
+			v = v<<8 | uint64(data[len(data)-1-i])
+		} else {
+			v = v<<8 | uint64(data[i])
+		}
+	}
+	str := strconv.FormatUint(v, base)
+	if d.Uppercase {
+		str = strings.ToUpper(str)
+	}
+	sb.WriteString(strings.Repeat("0", digits(len(data), base)-len(str)))
+	sb.WriteString(str)
+}
+
+// digits returns the number of digits of n-byte words in base.
+func digits(n, base int) int {
+	switch base {
+	case 2:
+		return n * 8
+	case 8:
+		return (n*8 + 2) / 3
+	default:
+		return n * 2
+	}
+}
+
+type dumpWriter struct {
+	d      *Dumper
+	w      io.Writer
+	offset uint64
+	buf    []byte
+}
+
+func (w *dumpWriter) Write(p []byte) (int, error) {
+	perLine := w.d.bytesPerLine()
+	n := len(p)
+
+	for len(p) > 0 {
+		l := perLine - len(w.buf)
+		if l > len(p) {
+			l = len(p)
+		}
+		w.buf = append(w.buf, p[:l]...)
+		p = p[l:]
+		if len(w.buf) == perLine {
+			if err := w.flush(); err != nil {
+				return n - len(p), err
+			}
+		}
+	}
+	return n, nil
+}
+
+func (w *dumpWriter) flush() error {
+	var sb strings.Builder
+	w.d.line(&sb, w.offset, w.buf, nil)
+	w.offset += uint64(len(w.buf))
+	w.buf = w.buf[:0]
+	_, err := io.WriteString(w.w, sb.String())
+	return err
+}
+
+func (w *dumpWriter) Close() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	return w.flush()
+}
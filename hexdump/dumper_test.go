@@ -0,0 +1,225 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package hexdump
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"testing"
+)
+
+func TestDumperDefault(t *testing.T) {
+	for _, input := range hexDumpTests {
+		ibuf := []byte(input)
+		dump := NewDumper().Dump(ibuf)
+		if dump != hex.Dump(ibuf) {
+			t.Errorf("Dump: got\n%s\nexpected\n%s", dump, hex.Dump(ibuf))
+		}
+	}
+}
+
+var dumperTests = []struct {
+	d      Dumper
+	input  string
+	output string
+}{
+	{
+		d: Dumper{
+			BytesPerLine: 8,
+			Group:        2,
+			OffsetWidth:  4,
+			Offset:       0x100,
+			Uppercase:    true,
+		},
+		input: "Hello, world!",
+		output: `0100  4865 6C6C  6F2C 2077  |Hello, w|
+0108  6F72 6C64  21         |orld!|
+`,
+	},
+	{
+		d: Dumper{
+			BytesPerLine: 6,
+			Group:        4,
+			NoASCII:      true,
+		},
+		input: "Hello, world!",
+		output: `00000000  48656c6c  6f2c
+00000006  20776f72  6c64
+0000000c  21
+`,
+	},
+}
+
+var viewTests = []struct {
+	d      Dumper
+	input  string
+	output string
+}{
+	{
+		d: Dumper{
+			Group:        4,
+			LittleEndian: true,
+			OffsetWidth:  4,
+		},
+		input:  "Hello, world!\n",
+		output: "0000  6c6c6548 77202c6f  646c726f     0a21  |Hello, world!.|\n",
+	},
+	{
+		d: Dumper{
+			BytesPerLine: 8,
+			Group:        8,
+			LittleEndian: true,
+			OffsetWidth:  4,
+			NoASCII:      true,
+		},
+		input: "Hello, world!\n",
+		output: `0000  77202c6f6c6c6548
+0008      0a21646c726f
+`,
+	},
+	{
+		d: Dumper{
+			BytesPerLine: 8,
+			Group:        2,
+			Base:         8,
+			OffsetWidth:  4,
+		},
+		input: "Hello, world!",
+		output: `0000  044145 066154  067454 020167  |Hello, w|
+0008  067562 066144  041            |orld!|
+`,
+	},
+	{
+		d: Dumper{
+			BytesPerLine: 4,
+			Group:        2,
+			Base:         2,
+			LittleEndian: true,
+			OffsetWidth:  4,
+		},
+		input:  "Hi!",
+		output: "0000  0110100101001000          00100001  |Hi!|\n",
+	},
+	{
+		d: Dumper{
+			BytesPerLine: 4,
+			Base:         8,
+			OffsetWidth:  4,
+			Charset:      CharsetLatin1,
+		},
+		input:  "\xe9t\xe9\x85",
+		output: "0000  351 164  351 205  |été.|\n",
+	},
+}
+
+func TestDumperViews(t *testing.T) {
+	for idx, test := range viewTests {
+		dump := test.d.Dump([]byte(test.input))
+		if dump != test.output {
+			t.Errorf("%d Dump: got\n%s\nexpected\n%s", idx, dump, test.output)
+		}
+	}
+}
+
+func TestDumper(t *testing.T) {
+	for idx, test := range dumperTests {
+		dump := test.d.Dump([]byte(test.input))
+		if dump != test.output {
+			t.Errorf("%d Dump: got\n%s\nexpected\n%s", idx, dump, test.output)
+		}
+	}
+}
+
+func TestDumperRoundTrip(t *testing.T) {
+	data := make([]byte, 300)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+	for _, perLine := range []int{1, 3, 8, 16, 20, 32} {
+		for _, group := range []int{1, 2, 4, 8} {
+			for _, width := range []int{4, 8, 12, 20} {
+				for _, upper := range []bool{false, true} {
+					for _, noASCII := range []bool{false, true} {
+						for _, l := range []int{0, 1, 7, 16, 33, 300} {
+							little := l%2 == 1
+							d := &Dumper{
+								BytesPerLine: perLine,
+								Group:        group,
+								LittleEndian: little,
+								OffsetWidth:  width,
+								Offset:       0xfff0,
+								Uppercase:    upper,
+								NoASCII:      noASCII,
+							}
+							name := fmt.Sprintf("%d/%d/%d/%v/%v/%d",
+								perLine, group, width, upper, noASCII, l)
+							p := &Parser{
+								LittleEndian: little,
+							}
+							dump := d.Dump(data[:l])
+							result, err := p.Parse([]byte(dump))
+							if err != nil {
+								t.Fatalf("%s: Parse failed: %s", name, err)
+							}
+							if !bytes.Equal(result, data[:l]) {
+								t.Fatalf("%s: round-trip failed:\n%s",
+									name, dump)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestDumperBases(t *testing.T) {
+	data := make([]byte, 100)
+	for i := range data {
+		data[i] = byte(i * 37)
+	}
+	for _, base := range []int{16, 8, 2} {
+		for _, group := range []int{1, 2, 4, 8} {
+			for _, little := range []bool{false, true} {
+				for _, l := range []int{1, 7, 16, 33, 100} {
+					d := &Dumper{
+						Group:        group,
+						LittleEndian: little,
+						Base:         base,
+					}
+					name := fmt.Sprintf("%d/%d/%v/%d", base, group, little, l)
+					dump := d.Dump(data[:l])
+					p := &Parser{
+						LittleEndian: little,
+						Base:         base,
+						Strict:       true,
+					}
+					result, err := p.Parse([]byte(dump))
+					if err != nil {
+						t.Fatalf("%s: Parse failed: %s\n%s", name, err, dump)
+					}
+					if !bytes.Equal(result, data[:l]) {
+						t.Fatalf("%s: round-trip failed:\n%s", name, dump)
+					}
+					// The dump must not parse with the default base.
+					// The single lines of 8 and 16 digit binary groups
+					// are also valid hex lines.
+					if base == 16 ||
+						base == 2 && l <= 16 && (group <= 2 || l <= 2) {
+						continue
+					}
+					_, err = Parse([]byte(dump))
+					if err == nil {
+						t.Errorf("%s: Parse with base 16 succeeded:\n%s",
+							name, dump)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,475 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package hexdump
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Format defines hexdump formats.
+type Format int
+
+// Hexdump formats.
+const (
+	// FormatAuto detects the format from the data.
+	FormatAuto Format = iota
+	// FormatHex is the encoding/hex.Dump, Dumper, and hexdump -C
+	// format: an offset followed by blank separated hex groups and
+	// an optional ASCII pane delimited by '|'.
+	FormatHex
+	// FormatOD is the od -A x -t x1z format with the ASCII pane
+	// delimited by '>' and '<'.
+	FormatOD
+	// FormatXXD is the xxd format with a colon after the offset.
+	FormatXXD
+	// FormatTcpdump is the tcpdump -x and -X format with 0x-prefixed
+	// offsets.
+	FormatTcpdump
+	// FormatWireshark is the Wireshark "Copy as Hex Dump" format.
+	FormatWireshark
+)
+
+var formats = map[Format]string{
+	FormatAuto:      "auto",
+	FormatHex:       "hex",
+	FormatOD:        "od",
+	FormatXXD:       "xxd",
+	FormatTcpdump:   "tcpdump",
+	FormatWireshark: "wireshark",
+}
+
+func (f Format) String() string {
+	name, ok := formats[f]
+	if ok {
+		return name
+	}
+	return fmt.Sprintf("{Format %d}", f)
+}
+
+// lineKind defines the kinds of dump lines.
+type lineKind int
+
+const (
+	lineInvalid lineKind = iota
+	lineData
+	lineSkip
+)
+
+// lineInfo describes a parsed dump line.
+type lineInfo struct {
+	kind   lineKind
+	offset uint64
+	// pane is the ASCII pane of the line, or nil if the line does not
+	// have one. The paneCol is the index of the pane in the line.
+	pane    []byte
+	paneCol int
+	// groups holds the end indices of the hex groups in the decoded
+	// line data.
+	groups []int
+	// col is the 1-based column of the first malformed byte, or 0
+	// if the line is well formed.
+	col int
+	msg string
+}
+
+func (info *lineInfo) malformed(line []byte, i int, msg string) {
+	if info.col != 0 {
+		return
+	}
+	info.col = i + 1
+	if i < len(line) {
+		info.msg = fmt.Sprintf("%s %q", msg, line[i])
+	} else {
+		info.msg = msg
+	}
+}
+
+// lineParser decodes the data bytes of a dump line and appends them
+// to dst. The line information is returned in info. Lines with only
+// an offset, such as the last line of hexdump -C output, are data
+// lines without data bytes.
+type lineParser func(dst, line []byte, info *lineInfo) []byte
+
+var lineParsers = map[Format]lineParser{
+	FormatHex:       hexParser('|', '|', 16),
+	FormatOD:        hexParser('>', '<', 16),
+	FormatXXD:       parseXXD,
+	FormatTcpdump:   parseTcpdump,
+	FormatWireshark: parseWireshark,
+}
+
+// Detect detects the hexdump format of data. The format is decided
+// by the first line that looks like a data line. Lines without a
+// recognizable layout, such as the tcpdump packet headers, are
+// skipped. If data does not have any data lines, Detect returns
+// FormatHex.
+func Detect(data []byte) Format {
+	for len(data) > 0 {
+		var line []byte
+		line, data = nextLine(data)
+		format, ok := detectLine(line)
+		if ok {
+			return format
+		}
+	}
+	return FormatHex
+}
+
+func detectLine(line []byte) (Format, bool) {
+	i := skipBlanks(line, 0)
+	if bytes.HasPrefix(line[i:], []byte("0x")) {
+		j := skipXDigits(line, i+2)
+		if j > i+2 && j < len(line) && line[j] == ':' {
+			return FormatTcpdump, true
+		}
+	}
+
+	n := skipXDigits(line, 0)
+	if n == 0 {
+		return FormatAuto, false
+	}
+	if n+1 < len(line) && line[n] == ':' && line[n+1] == ' ' {
+		return FormatXXD, true
+	}
+	if n < 4 {
+		return FormatAuto, false
+	}
+	j := skipBlanks(line, n)
+	if j == n || j >= len(line) || !isXDigit(line[j]) {
+		return FormatAuto, false
+	}
+	// The Wireshark layout is checked first since its text pane is
+	// not delimited and it can contain the pane delimiters of the
+	// other formats.
+	switch {
+	case j-n == 3:
+		return FormatWireshark, true
+	case bytes.IndexByte(line, '|') >= 0:
+		return FormatHex, true
+	case bytes.IndexByte(line, '>') >= 0 && line[len(line)-1] == '<':
+		return FormatOD, true
+	default:
+		return FormatHex, true
+	}
+}
+
+// hexParser creates a parser for the lines with an offset of 4 to
+// 16 hex digits followed by blank separated groups of digits in
+// base. The hex groups are decoded in pairs of hex digits, and the
+// octal and binary groups as big-endian words with the digit counts
+// of the Dumper. All groups but the last must have the same length
+// of 1, 2, 4, or 8 bytes, and the last group can be shorter. The
+// groups are separated by at most two blanks, or wider gaps that are
+// padding of a partial little-endian word. The decoding stops at the
+// first wider gap, at the first token that does not start with a hex
+// digit, or that is not a valid group. The ASCII pane is delimited
+// by the open and close bytes. Lines with only the offset are
+// accepted as end offsets.
+func hexParser(open, close byte, base int) lineParser {
+	return func(dst, line []byte, info *lineInfo) []byte {
+		i := skipXDigits(line, 0)
+		if i < 4 || i > 16 {
+			return dst
+		}
+		info.offset = hexValue(line[:i])
+		if skipBlanks(line, i) == len(line) {
+			info.kind = lineData
+			return dst
+		}
+		start := len(dst)
+		for {
+			j := skipBlanks(line, i)
+			if j == i {
+				break
+			}
+			end := skipXDigits(line, j)
+			if end == j {
+				break
+			}
+			width := digits(8, base)
+			if len(info.groups) > 0 {
+				width = digits(info.groups[0], base)
+			}
+			if j-i > 2 && j-i > 2+width-(end-j) {
+				// The gap before the text pane.
+				break
+			}
+			if base == 16 && (end-j)%2 != 0 {
+				info.malformed(line, end-1, "odd number of hex digits")
+				break
+			}
+			n := len(dst)
+			var ok bool
+			dst, ok = decodeGroup(dst, line[j:end], base)
+			size := len(dst) - n
+			next := skipBlanks(line, end)
+			switch {
+			case !ok:
+			case next < len(line) && isXDigit(line[next]):
+				ok = size == 1 || size == 2 || size == 4 || size == 8
+				if len(info.groups) > 0 && size != info.groups[0] {
+					ok = false
+				}
+			case len(info.groups) > 0:
+				ok = size <= info.groups[0]
+			default:
+				ok = size <= 8
+			}
+			if !ok {
+				dst = dst[:n]
+				info.malformed(line, j, "invalid "+baseNames[base]+" group")
+				break
+			}
+			info.groups = append(info.groups, len(dst)-start)
+			i = end
+		}
+		if len(dst) == start {
+			if info.col != 0 {
+				// The line has an offset and a malformed first group.
+				info.kind = lineData
+			}
+			return dst
+		}
+		info.kind = lineData
+
+		j := skipBlanks(line, i)
+		last := len(line)
+		for last > j && isBlank(line[last-1]) {
+			last--
+		}
+		switch {
+		case j == last:
+		case j == i:
+			info.malformed(line, j, "invalid hex digit")
+		case line[j] == open && last-j >= 2 && line[last-1] == close:
+			info.pane = line[j+1 : last-1]
+			info.paneCol = j + 1
+		default:
+			info.malformed(line, j, "invalid ASCII pane")
+		}
+		return dst
+	}
+}
+
+func parseXXD(dst, line []byte, info *lineInfo) []byte {
+	i := skipXDigits(line, 0)
+	if i == 0 || i > 16 || i >= len(line) || line[i] != ':' {
+		return dst
+	}
+	info.offset = hexValue(line[:i])
+	return parseGroups(dst, line, i+1, 1, false, info)
+}
+
+func parseTcpdump(dst, line []byte, info *lineInfo) []byte {
+	i := skipBlanks(line, 0)
+	if i == 0 {
+		// Packet header lines start from the first column.
+		info.kind = lineSkip
+		return dst
+	}
+	if !bytes.HasPrefix(line[i:], []byte("0x")) {
+		return dst
+	}
+	i += 2
+	j := skipXDigits(line, i)
+	if j == i || j-i > 16 || j >= len(line) || line[j] != ':' {
+		return dst
+	}
+	info.offset = hexValue(line[i:j])
+	return parseGroups(dst, line, j+1, 1, false, info)
+}
+
+func parseWireshark(dst, line []byte, info *lineInfo) []byte {
+	i := skipXDigits(line, 0)
+	if i < 4 || i > 16 {
+		return dst
+	}
+	info.offset = hexValue(line[:i])
+	return parseGroups(dst, line, i, 2, true, info)
+}
+
+// parseGroups decodes the blank separated hex groups starting from
+// line[i:] and appends them to dst. The groups after the first one
+// must be separated by at most maxGap blanks. If bytesOnly is set,
+// each group must contain exactly one byte. The decoding stops at the
+// first token that is not a valid group. The rest of the line is the
+// ASCII pane. Since the pane is not delimited and it can start with
+// spaces, it is taken from the end of the line, one character for
+// each data byte.
+func parseGroups(dst, line []byte, i, maxGap int, bytesOnly bool,
+	info *lineInfo) []byte {
+
+	start := len(dst)
+	var gap int
+	for {
+		gap = i
+		i = skipBlanks(line, i)
+		if i == gap || (len(dst) > start && i-gap > maxGap) {
+			break
+		}
+		end := skipXDigits(line, i)
+		n := end - i
+		if n == 0 || n%2 != 0 || (bytesOnly && n != 2) {
+			break
+		}
+		if end < len(line) && !isBlank(line[end]) {
+			break
+		}
+		for ; i < end; i += 2 {
+			dst = append(dst, hex2bin(line[i])<<4|hex2bin(line[i+1]))
+		}
+		info.groups = append(info.groups, len(dst)-start)
+	}
+	n := len(dst) - start
+	if n == 0 {
+		return dst
+	}
+	info.kind = lineData
+
+	switch {
+	case i == len(line):
+	case i-gap <= maxGap:
+		if isXDigit(line[i]) {
+			info.malformed(line, i, "invalid hex group")
+		} else {
+			info.malformed(line, i, "invalid hex digit")
+		}
+	default:
+		pane := len(line) - n
+		if pane > i || pane < gap+maxGap+1 {
+			pane = i
+		}
+		info.pane = line[pane:]
+		info.paneCol = pane
+	}
+	return dst
+}
+
+var baseNames = map[int]string{
+	2:  "binary",
+	8:  "octal",
+	16: "hex",
+}
+
+// formatParser returns the line parser for the format and base. The
+// octal and binary groups are supported in the FormatHex and
+// FormatOD formats.
+func formatParser(format Format, base int) (lineParser, error) {
+	if base == 16 {
+		parse, ok := lineParsers[format]
+		if !ok {
+			return nil, fmt.Errorf("unsupported hexdump format %s", format)
+		}
+		return parse, nil
+	}
+	switch format {
+	case FormatHex:
+		return hexParser('|', '|', base), nil
+	case FormatOD:
+		return hexParser('>', '<', base), nil
+	default:
+		return nil, fmt.Errorf("base %d is not supported in the %s format",
+			base, format)
+	}
+}
+
+// decodeGroup decodes the group digits in base and appends the bytes
+// to dst. The function returns false if the group is not valid in
+// base.
+func decodeGroup(dst, group []byte, base int) ([]byte, bool) {
+	if base == 16 {
+		for i := 0; i+1 < len(group); i += 2 {
+			dst = append(dst, hex2bin(group[i])<<4|hex2bin(group[i+1]))
+		}
+		return dst, true
+	}
+	var n int
+	for b := 1; b <= 8; b++ {
+		if digits(b, base) == len(group) {
+			n = b
+			break
+		}
+	}
+	if n == 0 {
+		return dst, false
+	}
+	shift := 1
+	if base == 8 {
+		shift = 3
+	}
+	var v uint64
+	for _, ch := range group {
+		d := uint64(hex2bin(ch))
+		if d >= uint64(base) || v>>(64-shift) != 0 {
+			return dst, false
+		}
+		v = v<<shift | d
+	}
+	if n < 8 && v>>(8*n) != 0 {
+		return dst, false
+	}
+	for i := n - 1; i >= 0; i-- {
+		dst = append(dst, byte(v>>(8*i)))
+	}
+	return dst, true
+}
+
+// isRepeat tests if the line is the repeat marker '*' that replaces
+// lines identical to the previous line.
+func isRepeat(line []byte) bool {
+	line = line[skipBlanks(line, 0):]
+	return len(line) > 0 && line[0] == '*' && skipBlanks(line, 1) == len(line)
+}
+
+// hexValue returns the value of the hex digits.
+func hexValue(digits []byte) uint64 {
+	var v uint64
+	for _, ch := range digits {
+		v = v<<4 | uint64(hex2bin(ch))
+	}
+	return v
+}
+
+// nextLine splits the first line from data. The line is returned
+// without the line terminator.
+func nextLine(data []byte) (line, rest []byte) {
+	idx := bytes.IndexByte(data, '\n')
+	if idx < 0 {
+		line = data
+	} else {
+		line = data[:idx]
+		rest = data[idx+1:]
+	}
+	if len(line) > 0 && line[len(line)-1] == '\r' {
+		line = line[:len(line)-1]
+	}
+	return
+}
+
+func skipBlanks(line []byte, i int) int {
+	for i < len(line) && isBlank(line[i]) {
+		i++
+	}
+	return i
+}
+
+func skipXDigits(line []byte, i int) int {
+	for i < len(line) && isXDigit(line[i]) {
+		i++
+	}
+	return i
+}
+
+func isBlank(ch byte) bool {
+	return ch == ' ' || ch == '\t'
+}
+
+func isXDigit(ch byte) bool {
+	return '0' <= ch && ch <= '9' || 'a' <= ch && ch <= 'f' ||
+		'A' <= ch && ch <= 'F'
+}
@@ -0,0 +1,193 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package hexdump
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var corpusTests = []struct {
+	file   string
+	format Format
+	bin    string
+}{
+	{
+		file:   "hexdump.txt",
+		format: FormatHex,
+		bin:    "sample.bin",
+	},
+	{
+		file:   "od.txt",
+		format: FormatOD,
+		bin:    "sample.bin",
+	},
+	{
+		file:   "xxd.txt",
+		format: FormatXXD,
+		bin:    "sample.bin",
+	},
+	{
+		file:   "tcpdump.txt",
+		format: FormatTcpdump,
+		bin:    "sample.bin",
+	},
+	{
+		file:   "wireshark.txt",
+		format: FormatWireshark,
+		bin:    "sample.bin",
+	},
+	{
+		file:   "wireshark-pipe.txt",
+		format: FormatWireshark,
+		bin:    "wireshark-pipe.bin",
+	},
+	{
+		file:   "hexdump-repeat.txt",
+		format: FormatHex,
+		bin:    "repeat.bin",
+	},
+	{
+		file:   "od-repeat.txt",
+		format: FormatOD,
+		bin:    "repeat.bin",
+	},
+	{
+		file:   "xxd-repeat.txt",
+		format: FormatXXD,
+		bin:    "repeat.bin",
+	},
+}
+
+func TestCorpus(t *testing.T) {
+	for _, test := range corpusTests {
+		expected, err := os.ReadFile(filepath.Join("testdata", test.bin))
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := os.ReadFile(filepath.Join("testdata", test.file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		format := Detect(data)
+		if format != test.format {
+			t.Errorf("%s: Detect=%v, expected %v", test.file, format,
+				test.format)
+		}
+		for _, f := range []Format{FormatAuto, test.format} {
+			result, err := ParseFormat(data, f)
+			if err != nil {
+				t.Errorf("%s: ParseFormat(%v) failed: %v", test.file, f, err)
+				continue
+			}
+			if !bytes.Equal(result, expected) {
+				t.Errorf("%s: ParseFormat(%v): got\n%sexpected\n%s",
+					test.file, f, hex.Dump(result), hex.Dump(expected))
+			}
+		}
+		p := &Parser{
+			Strict:      true,
+			VerifyASCII: true,
+		}
+		result, err := p.Parse(data)
+		if err != nil {
+			t.Errorf("%s: strict Parse failed: %v", test.file, err)
+		} else if !bytes.Equal(result, expected) {
+			t.Errorf("%s: strict Parse: got\n%sexpected\n%s",
+				test.file, hex.Dump(result), hex.Dump(expected))
+		}
+	}
+}
+
+var formatTests = []struct {
+	input  string
+	format Format
+	output string
+}{
+	{
+		input:  "00000000: 6162 6364  abcd\n",
+		format: FormatXXD,
+		output: "abcd",
+	},
+	{
+		input:  "00000000: 61 62 63 64  abcd\r\n",
+		format: FormatXXD,
+		output: "abcd",
+	},
+	{
+		input:  "\t0x0000:  6162 63                                  abc\n",
+		format: FormatTcpdump,
+		output: "abc",
+	},
+	{
+		input:  "0000   61 62 63 64                                       ab cd\n",
+		format: FormatWireshark,
+		output: "abcd",
+	},
+	{
+		input:  "0000   61 7c 62   a|b\n0003   63 64      cd\n",
+		format: FormatWireshark,
+		output: "a|bcd",
+	},
+	{
+		input:  "0000  6162 6364\n",
+		format: FormatHex,
+		output: "abcd",
+	},
+}
+
+func TestFormats(t *testing.T) {
+	for _, test := range formatTests {
+		format := Detect([]byte(test.input))
+		if format != test.format {
+			t.Errorf("Detect(%q)=%v, expected %v", test.input, format,
+				test.format)
+		}
+		result, err := Parse([]byte(test.input))
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", test.input, err)
+			continue
+		}
+		if string(result) != test.output {
+			t.Errorf("Parse(%q)=%q, expected %q", test.input, result,
+				test.output)
+		}
+	}
+}
+
+func TestFormatErrors(t *testing.T) {
+	_, err := ParseFormat([]byte("00000000: 6162 6364  abcd\n"), FormatHex)
+	if err == nil {
+		t.Errorf("ParseFormat succeeded with wrong format")
+	}
+
+	// The Wireshark text pane is not decoded as hex groups.
+	wireshark := []byte("0000   61 7c 62   a|b\n0003   63 64      cd\n")
+	result, err := ParseFormat(wireshark, FormatHex)
+	if err != nil || string(result) != "a|bcd" {
+		t.Errorf("ParseFormat(FormatHex)=%q, %v, expected %q", result, err,
+			"a|bcd")
+	}
+	p := &Parser{
+		Format: FormatHex,
+		Strict: true,
+	}
+	if _, err = p.Parse(wireshark); err == nil {
+		t.Errorf("strict Parse succeeded with wrong format")
+	}
+
+	_, err = ParseFormat([]byte("0000  6162\n"), Format(100))
+	if err == nil {
+		t.Errorf("ParseFormat succeeded with unknown format")
+	}
+	if Format(100).String() != "{Format 100}" {
+		t.Errorf("unexpected name: %s", Format(100))
+	}
+}
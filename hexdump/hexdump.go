@@ -1,5 +1,5 @@
 //
-// Copyright (c) 2021-2024 Markku Rossi
+// Copyright (c) 2021-2026 Markku Rossi
 //
 // All rights reserved.
 //
@@ -8,41 +8,168 @@ package hexdump
 
 import (
 	"bytes"
-	"errors"
-	"regexp"
+	"fmt"
+	"io"
 )
 
-var reLine = regexp.MustCompilePOSIX(`^[[:xdigit:]]{7,8}(([[:blank:]]+[[:xdigit:]]+){1,16}).*$`)
-var reByte = regexp.MustCompilePOSIX(`([[:xdigit:]]{2})`)
+// GapMode defines how gaps between the line offsets are handled.
+type GapMode int
 
-// Parse parses data from the encoding/hex.Dump formatted output.
+// Gap modes.
+const (
+	// GapError reports gaps and overlapping offsets as errors.
+	GapError GapMode = iota
+	// GapFill fills gaps with zero bytes. Overlapping offsets are
+	// reported as errors.
+	GapFill
+	// GapIgnore concatenates the line data ignoring gaps and
+	// overlapping offsets.
+	GapIgnore
+)
+
+var gapModes = map[GapMode]string{
+	GapError:  "error",
+	GapFill:   "fill",
+	GapIgnore: "ignore",
+}
+
+func (m GapMode) String() string {
+	name, ok := gapModes[m]
+	if ok {
+		return name
+	}
+	return fmt.Sprintf("{GapMode %d}", m)
+}
+
+// Parser implements a configurable hexdump parser. The parser
+// validates the line offsets and expands the repeat marker '*' that
+// replaces lines identical to the previous line. The decoded data
+// starts from the offset of the first data line.
+type Parser struct {
+	// Format specifies the dump format. The zero value FormatAuto
+	// detects the format from the data.
+	Format Format
+	// Gaps specifies how gaps between the line offsets are handled.
+	Gaps GapMode
+	// Strict enables strict parsing. In the strict mode, all
+	// non-blank lines must be data lines, end offsets, or repeat
+	// markers, and the data lines must not have malformed hex groups
+	// or ASCII panes. The tcpdump packet header lines are allowed.
+	// Without the strict mode, the malformed lines are skipped and
+	// the line decoding stops at the first malformed hex group.
+	Strict bool
+	// VerifyASCII enables the verification of the ASCII panes
+	// against the decoded data bytes.
+	VerifyASCII bool
+	// LittleEndian specifies that the hex groups are little-endian
+	// words, as in the Dumper output with the LittleEndian option.
+	// The bytes of each group are reversed into the memory order.
+	LittleEndian bool
+	// Base specifies the number base of the groups: 16, 8, or 2, as
+	// in the Dumper output with the Base option. The zero value uses
+	// 16. The octal and binary groups are supported in the FormatHex
+	// and FormatOD formats.
+	Base int
+}
+
+// ParseError describes a malformed hexdump line.
+type ParseError struct {
+	// Line is the 1-based line number.
+	Line int
+	// Column is the 1-based byte column in the line.
+	Column int
+	Msg    string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Msg)
+}
+
+// NewParser creates a new parser with the default configuration.
+func NewParser() *Parser {
+	return &Parser{}
+}
+
+// Parse parses hexdump data. The dump format is detected
+// automatically with the Detect function.
 func Parse(data []byte) ([]byte, error) {
-	var result bytes.Buffer
+	return NewParser().Parse(data)
+}
+
+// ParseFormat parses hexdump data in the argument format. If format
+// is FormatAuto, the format is detected with the Detect function.
+func ParseFormat(data []byte, format Format) ([]byte, error) {
+	p := &Parser{
+		Format: format,
+	}
+	return p.Parse(data)
+}
 
+// Parse parses hexdump data.
+func (p *Parser) Parse(data []byte) ([]byte, error) {
 	if len(data) == 0 {
 		return nil, nil
 	}
+	result, err := io.ReadAll(p.decoder(data, p.Gaps))
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ParseSparse parses partial hexdump data. The function returns the
+// contiguous runs of data keyed by their start offsets. The gaps
+// between runs are not errors but the runs must be in increasing
+// offset order.
+func (p *Parser) ParseSparse(data []byte) (map[uint64][]byte, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	d := p.decoder(data, GapIgnore)
+
+	result := make(map[uint64][]byte)
+	var run []byte
+	var start, end uint64
+	var buf [4096]byte
 
 	for {
-		match := reLine.FindSubmatchIndex(data)
-		if match == nil {
-			if result.Len() == 0 {
-				return nil, errors.New("invalid hexdump data")
+		n, err := d.Read(buf[:])
+		if n > 0 {
+			offset := d.Offset() - uint64(n)
+			if run != nil && offset != end {
+				if offset < end {
+					return nil, fmt.Errorf(
+						"offset %x overlaps data ending at %x", offset, end)
+				}
+				result[start] = run
+				run = nil
 			}
-			return result.Bytes(), nil
-		}
-		bytes := data[match[2]:match[3]]
-		data = data[match[1]:]
-
-		for {
-			m := reByte.FindSubmatchIndex(bytes)
-			if m == nil {
-				break
+			if run == nil {
+				start = offset
 			}
-			result.WriteByte(hex2bin(bytes[m[2]])<<4 | hex2bin(bytes[m[2]+1]))
-			bytes = bytes[m[1]:]
+			run = append(run, buf[:n]...)
+			end = offset + uint64(n)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
 		}
 	}
+	if run != nil {
+		result[start] = run
+	}
+	return result, nil
+}
+
+func (p *Parser) decoder(data []byte, gaps GapMode) *Decoder {
+	parser := *p
+	parser.Gaps = gaps
+	if parser.Format == FormatAuto {
+		parser.Format = Detect(data)
+	}
+	return parser.Decoder(bytes.NewReader(data))
 }
 
 func hex2bin(h byte) byte {
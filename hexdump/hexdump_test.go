@@ -1,5 +1,5 @@
 //
-// Copyright (c) 2021-2024 Markku Rossi
+// Copyright (c) 2021-2026 Markku Rossi
 //
 // All rights reserved.
 //
@@ -9,6 +9,7 @@ package hexdump
 import (
 	"bytes"
 	"encoding/hex"
+	"reflect"
 	"testing"
 )
 
@@ -66,3 +67,251 @@ func TestHexdumpParse(t *testing.T) {
 		}
 	}
 }
+
+var gapTests = []struct {
+	input  string
+	gaps   GapMode
+	output string
+	err    bool
+}{
+	{
+		input: "0010  61 62\n0014  65\n",
+		gaps:  GapError,
+		err:   true,
+	},
+	{
+		input:  "0010  61 62\n0014  65\n",
+		gaps:   GapFill,
+		output: "ab\x00\x00e",
+	},
+	{
+		input:  "0010  61 62\n0014  65\n",
+		gaps:   GapIgnore,
+		output: "abe",
+	},
+	{
+		input: "0010  61 62\n0011  63\n",
+		gaps:  GapFill,
+		err:   true,
+	},
+	{
+		input:  "0010  61 62\n0011  63\n",
+		gaps:   GapIgnore,
+		output: "abc",
+	},
+	{
+		input:  "0000  61 62\n*\n0006\n",
+		gaps:   GapError,
+		output: "ababab",
+	},
+	{
+		input:  "0000  61 62\n*\n0005  63\n",
+		gaps:   GapError,
+		output: "ababac",
+	},
+	{
+		input: "0000  61 62\n*\n",
+		gaps:  GapError,
+		err:   true,
+	},
+	{
+		input:  "0000  61 62\n0008\n",
+		gaps:   GapFill,
+		output: "ab\x00\x00\x00\x00\x00\x00",
+	},
+}
+
+func TestGaps(t *testing.T) {
+	for _, test := range gapTests {
+		p := &Parser{
+			Gaps: test.gaps,
+		}
+		result, err := p.Parse([]byte(test.input))
+		if test.err {
+			if err == nil {
+				t.Errorf("Parse(%q, %v) succeeded", test.input, test.gaps)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q, %v) failed: %v", test.input, test.gaps, err)
+			continue
+		}
+		if string(result) != test.output {
+			t.Errorf("Parse(%q, %v)=%q, expected %q", test.input, test.gaps,
+				result, test.output)
+		}
+	}
+}
+
+func TestParseSparse(t *testing.T) {
+	input := `00000010  61 62 63 64  |abcd|
+00000014  65 66        |ef|
+*
+0000001a  67           |g|
+00000100  68           |h|
+0000010a
+`
+	result, err := NewParser().ParseSparse([]byte(input))
+	if err != nil {
+		t.Fatalf("ParseSparse failed: %v", err)
+	}
+	expected := map[uint64][]byte{
+		0x10:  []byte("abcdefefefg"),
+		0x100: []byte("h"),
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ParseSparse: got %q, expected %q", result, expected)
+	}
+
+	_, err = NewParser().ParseSparse([]byte("0010  61 62\n0000  63\n"))
+	if err == nil {
+		t.Errorf("ParseSparse succeeded with overlapping offsets")
+	}
+}
+
+var strictTests = []struct {
+	input  string
+	verify bool
+	line   int
+	column int
+}{
+	{
+		input:  "00000000  61 6g 63  |abc|\n",
+		line:   1,
+		column: 14,
+	},
+	{
+		input:  "00000000  61 62 63  |abc|\n00000003  64 6  |d|\n",
+		line:   2,
+		column: 14,
+	},
+	{
+		input:  "00000000  61 62 63  |abc\n",
+		line:   1,
+		column: 21,
+	},
+	{
+		input:  "00000000  61 62 63  |abc|\nhello\n",
+		line:   2,
+		column: 1,
+	},
+	{
+		input:  "00000000: 6162 6x  ab.\n",
+		line:   1,
+		column: 16,
+	},
+	{
+		input:  "000000 61 62 63  >abc<\n000004 64  >d<\n",
+		line:   2,
+		column: 1,
+	},
+	{
+		input:  "*\n00000000  61  |a|\n",
+		line:   1,
+		column: 1,
+	},
+	{
+		input:  "00000000  61 62 63  |abd|\n",
+		verify: true,
+		line:   1,
+		column: 24,
+	},
+	{
+		input:  "00000000  61 0a 63  |a c|\n",
+		verify: true,
+		line:   1,
+		column: 23,
+	},
+	{
+		input:  "00000000: 6162 63  ab\n",
+		verify: true,
+		line:   1,
+		column: 22,
+	},
+	{
+		input:  "0000   61 62 63   abcd\n",
+		verify: true,
+		line:   1,
+		column: 22,
+	},
+}
+
+func TestStrict(t *testing.T) {
+	for _, test := range strictTests {
+		p := &Parser{
+			Strict:      true,
+			VerifyASCII: test.verify,
+		}
+		_, err := p.Parse([]byte(test.input))
+		perr, ok := err.(*ParseError)
+		if !ok {
+			t.Errorf("Parse(%q): expected *ParseError, got %v", test.input, err)
+			continue
+		}
+		if perr.Line != test.line || perr.Column != test.column {
+			t.Errorf("Parse(%q): got error %v, expected %d:%d", test.input,
+				perr, test.line, test.column)
+		}
+	}
+}
+
+func TestBaseErrors(t *testing.T) {
+	for _, test := range []struct {
+		input string
+		base  int
+		err   string
+	}{
+		{
+			input: "00000000  110 145  |He|\n",
+			err:   "1:13: odd number of hex digits '0'",
+		},
+		{
+			input: "00000000  044145 066154  |Hell|\n",
+			err:   "1:11: invalid hex group '0'",
+		},
+		{
+			input: "00000000  01001000 01100101  |He|\n" +
+				"00000002  01101100 01101100  |ll|\n",
+			err: "1:1: 8 bytes decoded for offsets 0-1: groups do not match base 16",
+		},
+		{
+			input: "00000000  400 145  |.e|\n",
+			base:  8,
+			err:   "1:11: invalid octal group '4'",
+		},
+		{
+			input: "00000000  0100100 01100101  |He|\n",
+			base:  2,
+			err:   "1:11: invalid binary group '0'",
+		},
+		{
+			input: "00000000: 0100  ..\n",
+			base:  8,
+			err:   "base 8 is not supported in the xxd format",
+		},
+	} {
+		p := &Parser{
+			Base: test.base,
+		}
+		_, err := p.Parse([]byte(test.input))
+		if err == nil || err.Error() != test.err {
+			t.Errorf("Parse(%q): got error %v, expected %v", test.input, err,
+				test.err)
+		}
+	}
+}
+
+func TestLenient(t *testing.T) {
+	input := `garbage
+00000000  61 62 63  |abc|
+00000003  64 6g     |d?|
+`
+	result, err := Parse([]byte(input))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if string(result) != "abcd" {
+		t.Errorf("Parse: got %q, expected %q", result, "abcd")
+	}
+}
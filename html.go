@@ -1,5 +1,5 @@
 //
-// Copyright (c) 2021 Markku Rossi
+// Copyright (c) 2021-2026 Markku Rossi
 //
 // All rights reserved.
 //
@@ -22,6 +22,20 @@ func (text *Text) HTML() string {
 			continue
 		}
 
+		var style string
+		if span.FG != nil {
+			style += "color:" + HexColor(span.FG)
+		}
+		if span.BG != nil {
+			if len(style) > 0 {
+				style += ";"
+			}
+			style += "background-color:" + HexColor(span.BG)
+		}
+
+		if len(style) > 0 {
+			str += "<span style=\"" + style + "\">"
+		}
 		if span.Bold {
 			str += "<b>"
 		}
@@ -35,6 +49,9 @@ func (text *Text) HTML() string {
 		if span.Bold {
 			str += "</b>"
 		}
+		if len(style) > 0 {
+			str += "</span>"
+		}
 	}
 
 	return str
@@ -0,0 +1,152 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package literal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Generator generates byte literals.
+type Generator struct {
+	// Format specifies the literal format. The FormatAuto generates
+	// plain hex.
+	Format Format
+	// Name specifies the variable name of the C and Go formats. If
+	// the name is empty, only the array elements are generated for
+	// C, as xxd -i does for its standard input, and a []byte
+	// expression for Go.
+	Name string
+	// BytesPerLine specifies the number of bytes per output line. The
+	// zero value selects the format default: 12 for C and Go, 16 for
+	// escaped strings, and 30 for plain hex.
+	BytesPerLine int
+	// Uppercase specifies if the hex digits are in upper case.
+	Uppercase bool
+}
+
+// NewGenerator creates a new generator for the argument format.
+func NewGenerator(format Format) *Generator {
+	return &Generator{
+		Format: format,
+	}
+}
+
+// Generate generates the byte literal of data. The output ends with
+// a newline unless data and the variable name are both empty.
+func (g *Generator) Generate(data []byte) string {
+	var sb strings.Builder
+
+	switch g.Format {
+	case FormatC:
+		if len(g.Name) > 0 {
+			fmt.Fprintf(&sb, "unsigned char %s[] = {\n", g.Name)
+		}
+		g.lines(&sb, data, "  ", ", ", ",", "\n")
+		if len(g.Name) > 0 {
+			fmt.Fprintf(&sb, "};\nunsigned int %s_len = %d;\n",
+				g.Name, len(data))
+		}
+
+	case FormatGo:
+		if len(g.Name) > 0 {
+			fmt.Fprintf(&sb, "var %s = ", g.Name)
+		}
+		if len(data) == 0 {
+			sb.WriteString("[]byte{}\n")
+			break
+		}
+		sb.WriteString("[]byte{\n")
+		g.lines(&sb, data, "\t", ", ", ",", ",\n")
+		sb.WriteString("}\n")
+
+	case FormatEscaped:
+		perLine := g.bytesPerLine()
+		for len(data) > 0 {
+			l := perLine
+			if l > len(data) {
+				l = len(data)
+			}
+			sb.WriteByte('"')
+			for _, b := range data[:l] {
+				sb.WriteString(`\x`)
+				g.hex(&sb, b)
+			}
+			sb.WriteString("\"\n")
+			data = data[l:]
+		}
+
+	default:
+		perLine := g.bytesPerLine()
+		for len(data) > 0 {
+			l := perLine
+			if l > len(data) {
+				l = len(data)
+			}
+			for _, b := range data[:l] {
+				g.hex(&sb, b)
+			}
+			sb.WriteByte('\n')
+			data = data[l:]
+		}
+	}
+
+	return sb.String()
+}
+
+// lines writes data as 0x-prefixed array elements. The lines are
+// prefixed with indent, the elements are separated with sep, and the
+// lines end with eol except for the last line which ends with last.
+func (g *Generator) lines(sb *strings.Builder, data []byte,
+	indent, sep, eol, last string) {
+
+	perLine := g.bytesPerLine()
+	for len(data) > 0 {
+		l := perLine
+		if l > len(data) {
+			l = len(data)
+		}
+		sb.WriteString(indent)
+		for i, b := range data[:l] {
+			if i > 0 {
+				sb.WriteString(sep)
+			}
+			sb.WriteString("0x")
+			g.hex(sb, b)
+		}
+		data = data[l:]
+		if len(data) > 0 {
+			sb.WriteString(eol)
+			sb.WriteByte('\n')
+		} else {
+			sb.WriteString(last)
+		}
+	}
+}
+
+func (g *Generator) hex(sb *strings.Builder, b byte) {
+	digits := "0123456789abcdef"
+	if g.Uppercase {
+		digits = "0123456789ABCDEF"
+	}
+	sb.WriteByte(digits[b>>4])
+	sb.WriteByte(digits[b&0xf])
+}
+
+func (g *Generator) bytesPerLine() int {
+	if g.BytesPerLine > 0 {
+		return g.BytesPerLine
+	}
+	switch g.Format {
+	case FormatC, FormatGo:
+		return 12
+	case FormatEscaped:
+		return 16
+	default:
+		return 30
+	}
+}
@@ -0,0 +1,126 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package literal
+
+import (
+	"bytes"
+	"testing"
+)
+
+var hello = []byte("Hello, world!\n")
+
+var generateTests = []struct {
+	g      Generator
+	data   []byte
+	output string
+}{
+	{
+		g: Generator{
+			Format: FormatC,
+			Name:   "hello_txt",
+		},
+		data: hello,
+		output: `unsigned char hello_txt[] = {
+  0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x2c, 0x20, 0x77, 0x6f, 0x72, 0x6c, 0x64,
+  0x21, 0x0a
+};
+unsigned int hello_txt_len = 14;
+`,
+	},
+	{
+		g: Generator{
+			Format: FormatC,
+		},
+		data: hello,
+		output: `  0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x2c, 0x20, 0x77, 0x6f, 0x72, 0x6c, 0x64,
+  0x21, 0x0a
+`,
+	},
+	{
+		g: Generator{
+			Format: FormatC,
+			Name:   "empty_bin",
+		},
+		output: `unsigned char empty_bin[] = {
+};
+unsigned int empty_bin_len = 0;
+`,
+	},
+	{
+		g: Generator{
+			Format:       FormatGo,
+			Name:         "hello",
+			BytesPerLine: 8,
+			Uppercase:    true,
+		},
+		data: hello,
+		output: `var hello = []byte{
+	0x48, 0x65, 0x6C, 0x6C, 0x6F, 0x2C, 0x20, 0x77,
+	0x6F, 0x72, 0x6C, 0x64, 0x21, 0x0A,
+}
+`,
+	},
+	{
+		g: Generator{
+			Format: FormatGo,
+		},
+		output: "[]byte{}\n",
+	},
+	{
+		g: Generator{
+			Format:       FormatEscaped,
+			BytesPerLine: 4,
+		},
+		data: []byte("Hello!"),
+		output: `"\x48\x65\x6c\x6c"
+"\x6f\x21"
+`,
+	},
+	{
+		g: Generator{
+			Format: FormatHex,
+		},
+		data: bytes.Repeat([]byte{0xab}, 31),
+		output: "abababababababababababababababababababababababababababababab\n" +
+			"ab\n",
+	},
+}
+
+func TestGenerate(t *testing.T) {
+	for idx, test := range generateTests {
+		result := test.g.Generate(test.data)
+		if result != test.output {
+			t.Errorf("test %d: got\n%s\nexpected\n%s", idx, result, test.output)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	data := make([]byte, 256)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	for _, format := range []Format{FormatC, FormatGo, FormatEscaped, FormatHex} {
+		for _, name := range []string{"", "data"} {
+			g := NewGenerator(format)
+			g.Name = name
+			for _, input := range [][]byte{data, data[:1], nil} {
+				literal := g.Generate(input)
+				result, err := ParseFormat([]byte(literal), format)
+				if err != nil {
+					t.Errorf("%s: ParseFormat failed: %v\n%s", format, err,
+						literal)
+					continue
+				}
+				if !bytes.Equal(result, input) {
+					t.Errorf("%s: round-trip failed: got %x, expected %x",
+						format, result, input)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,536 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+// Package literal parses and generates byte literals: C arrays in the
+// xxd -i format, Go byte slices, escaped strings, and plain hex in
+// the xxd -p format.
+package literal
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Format defines byte literal formats.
+type Format int
+
+// Byte literal formats.
+const (
+	// FormatAuto detects the format from the data.
+	FormatAuto Format = iota
+	// FormatC is the C array format of xxd -i.
+	FormatC
+	// FormatGo is the Go []byte{...} literal format.
+	FormatGo
+	// FormatEscaped is the escaped string format "\x41\x42".
+	FormatEscaped
+	// FormatHex is the continuous plain hex format of xxd -p.
+	FormatHex
+)
+
+var formats = map[Format]string{
+	FormatAuto:    "auto",
+	FormatC:       "c",
+	FormatGo:      "go",
+	FormatEscaped: "escaped",
+	FormatHex:     "hex",
+}
+
+func (f Format) String() string {
+	name, ok := formats[f]
+	if ok {
+		return name
+	}
+	return fmt.Sprintf("{Format %d}", f)
+}
+
+// Detect detects the byte literal format of data. The comments and
+// the contents of strings and character literals are ignored. Data
+// with strings or backslashes is an escaped string. Data with braces
+// is an array literal, FormatGo if it has the []byte type and FormatC
+// otherwise. Data with 0x prefixes is a C element list, and all
+// other data is plain hex.
+func Detect(data []byte) Format {
+	data = skeleton(data)
+	switch {
+	case bytes.IndexByte(data, '"') >= 0 || bytes.IndexByte(data, '\\') >= 0:
+		return FormatEscaped
+	case bytes.Contains(data, []byte("[]byte")):
+		return FormatGo
+	case bytes.IndexByte(data, '{') >= 0:
+		return FormatC
+	case bytes.Contains(data, []byte("0x")), bytes.Contains(data, []byte("0X")):
+		return FormatC
+	default:
+		return FormatHex
+	}
+}
+
+// skeleton returns data without the C, Go, and '#' comments, and with
+// the contents of strings and character literals removed. The quotes
+// of strings are kept but character literals are removed completely
+// so that the quotes and backslashes of the array elements are not
+// taken as escaped strings.
+func skeleton(data []byte) []byte {
+	var result []byte
+	for i := 0; i < len(data); i++ {
+		switch {
+		case data[i] == '"':
+			result = append(result, '"')
+			for i++; i < len(data) && data[i] != '"' && data[i] != '\n'; i++ {
+				if data[i] == '\\' {
+					i++
+				}
+			}
+			if i < len(data) && data[i] == '"' {
+				result = append(result, '"')
+			}
+		case data[i] == '\'':
+			end := charLiteralEnd(data, i)
+			if end < 0 {
+				result = append(result, data[i])
+			} else {
+				i = end
+			}
+		case data[i] == '#', bytes.HasPrefix(data[i:], []byte("//")):
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			if i < len(data) {
+				result = append(result, '\n')
+			}
+		case bytes.HasPrefix(data[i:], []byte("/*")):
+			end := bytes.Index(data[i+2:], []byte("*/"))
+			if end < 0 {
+				return result
+			}
+			i += 2 + end + 1
+			result = append(result, ' ')
+		default:
+			result = append(result, data[i])
+		}
+	}
+	return result
+}
+
+// charLiteralEnd returns the index of the closing quote of the
+// character literal starting at data[i], or -1 if data[i] does not
+// start a character literal.
+func charLiteralEnd(data []byte, i int) int {
+	if i+2 < len(data) && data[i+1] != '\\' && data[i+2] == '\'' {
+		return i + 2
+	}
+	if i+1 >= len(data) || data[i+1] != '\\' {
+		return -1
+	}
+	// Escapes are at most \U and 8 hex digits.
+	for j := i + 3; j < len(data) && j <= i+12 && data[j] != '\n'; j++ {
+		if data[j] == '\'' {
+			return j
+		}
+	}
+	return -1
+}
+
+// Parse parses byte literal data. The format is detected with the
+// Detect function.
+func Parse(data []byte) ([]byte, error) {
+	return ParseFormat(data, FormatAuto)
+}
+
+// ParseFormat parses byte literal data in the argument format. The
+// C and Go comments and whitespace are ignored in all formats, and
+// the '#' comments in the plain hex format.
+//
+// The array formats parse the elements of the first brace-enclosed
+// list, or the whole data as an element list if data does not have
+// braces, as in the xxd -i output for the standard input. The
+// elements can be numbers in any C or Go base, negative
+// numbers of signed char arrays, or character literals.
+//
+// The escaped string format concatenates the contents of all quoted
+// strings. If data does not have quotes, the whole data, excluding
+// line breaks, is the string content.
+func ParseFormat(data []byte, format Format) ([]byte, error) {
+	if format == FormatAuto {
+		format = Detect(data)
+	}
+	s := &scanner{
+		data: data,
+		line: 1,
+		col:  1,
+	}
+	switch format {
+	case FormatC, FormatGo:
+		return s.parseArray()
+	case FormatEscaped:
+		return s.parseEscaped()
+	case FormatHex:
+		return s.parseHex()
+	default:
+		return nil, fmt.Errorf("unsupported literal format %s", format)
+	}
+}
+
+type scanner struct {
+	data []byte
+	pos  int
+	line int
+	col  int
+}
+
+func (s *scanner) eof() bool {
+	return s.pos >= len(s.data)
+}
+
+func (s *scanner) peek() byte {
+	return s.data[s.pos]
+}
+
+func (s *scanner) next() byte {
+	b := s.data[s.pos]
+	s.pos++
+	if b == '\n' {
+		s.line++
+		s.col = 1
+	} else {
+		s.col++
+	}
+	return b
+}
+
+func (s *scanner) errorf(format string, a ...interface{}) error {
+	return fmt.Errorf("%d:%d: %s", s.line, s.col, fmt.Sprintf(format, a...))
+}
+
+// skipSpace skips whitespace and comments. The hash argument
+// specifies if '#' starts a line comment.
+func (s *scanner) skipSpace(hash bool) error {
+	for !s.eof() {
+		rest := s.data[s.pos:]
+		switch {
+		case isSpace(rest[0]):
+			s.next()
+		case hash && rest[0] == '#', bytes.HasPrefix(rest, []byte("//")):
+			for !s.eof() && s.peek() != '\n' {
+				s.next()
+			}
+		case bytes.HasPrefix(rest, []byte("/*")):
+			s.next()
+			s.next()
+			for {
+				if s.eof() {
+					return s.errorf("unterminated comment")
+				}
+				if bytes.HasPrefix(s.data[s.pos:], []byte("*/")) {
+					s.next()
+					s.next()
+					break
+				}
+				s.next()
+			}
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func (s *scanner) parseArray() ([]byte, error) {
+	braces := bytes.IndexByte(s.data, '{') >= 0
+	for braces {
+		if err := s.skipSpace(false); err != nil {
+			return nil, err
+		}
+		if s.eof() {
+			return nil, s.errorf("missing '{'")
+		}
+		if s.next() == '{' {
+			break
+		}
+	}
+
+	result := []byte{}
+	for {
+		if err := s.skipSpace(false); err != nil {
+			return nil, err
+		}
+		if !braces && s.eof() {
+			return result, nil
+		}
+		if s.eof() {
+			return nil, s.errorf("missing '}'")
+		}
+		if braces && s.peek() == '}' {
+			return result, nil
+		}
+		b, err := s.parseElement()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, b)
+
+		if err := s.skipSpace(false); err != nil {
+			return nil, err
+		}
+		if !braces && s.eof() {
+			return result, nil
+		}
+		if s.eof() {
+			return nil, s.errorf("missing '}'")
+		}
+		switch {
+		case s.peek() == ',':
+			s.next()
+		case braces && s.peek() == '}':
+		default:
+			return nil, s.errorf("unexpected %q", s.peek())
+		}
+	}
+}
+
+func (s *scanner) parseElement() (byte, error) {
+	line, col := s.line, s.col
+
+	if s.peek() == '\'' {
+		s.next()
+		var r rune
+		var err error
+		if !s.eof() && s.peek() == '\\' {
+			r, err = s.parseEscape()
+			if err != nil {
+				return 0, err
+			}
+			if r < 0 {
+				r = -1 - r
+			}
+		} else if !s.eof() {
+			r = rune(s.next())
+		}
+		if s.eof() || s.next() != '\'' {
+			return 0, fmt.Errorf("%d:%d: invalid character literal",
+				line, col)
+		}
+		if r > 0xff {
+			return 0, fmt.Errorf("%d:%d: character literal out of range",
+				line, col)
+		}
+		return byte(r), nil
+	}
+
+	start := s.pos
+	for !s.eof() && isElement(s.peek()) {
+		s.next()
+	}
+	tok := string(s.data[start:s.pos])
+	if len(tok) == 0 {
+		return 0, fmt.Errorf("%d:%d: unexpected %q", line, col, s.peek())
+	}
+	num := tok
+	for len(num) > 1 && isSuffix(num[len(num)-1]) {
+		num = num[:len(num)-1]
+	}
+	v, err := strconv.ParseInt(num, 0, 64)
+	if err != nil || v < -128 || v > 255 {
+		return 0, fmt.Errorf("%d:%d: invalid byte value %q", line, col, tok)
+	}
+	return byte(v), nil
+}
+
+func (s *scanner) parseEscaped() ([]byte, error) {
+	result := []byte{}
+	if bytes.IndexByte(s.data, '"') < 0 {
+		for !s.eof() {
+			switch s.peek() {
+			case '\n', '\r':
+				s.next()
+			case '\\':
+				r, err := s.parseEscape()
+				if err != nil {
+					return nil, err
+				}
+				result = appendRune(result, r)
+			default:
+				result = append(result, s.next())
+			}
+		}
+		return result, nil
+	}
+
+	for {
+		if err := s.skipSpace(false); err != nil {
+			return nil, err
+		}
+		if s.eof() {
+			return result, nil
+		}
+		if s.next() != '"' {
+			continue
+		}
+		for {
+			if s.eof() || s.peek() == '\n' {
+				return nil, s.errorf("unterminated string")
+			}
+			if s.peek() == '"' {
+				s.next()
+				break
+			}
+			if s.peek() == '\\' {
+				r, err := s.parseEscape()
+				if err != nil {
+					return nil, err
+				}
+				result = appendRune(result, r)
+			} else {
+				result = append(result, s.next())
+			}
+		}
+	}
+}
+
+// parseEscape parses an escape sequence. The escapes \x and octal
+// escapes return byte values and \u and \U escapes return Unicode
+// code points.
+func (s *scanner) parseEscape() (rune, error) {
+	line, col := s.line, s.col
+	s.next()
+	if s.eof() {
+		return 0, fmt.Errorf("%d:%d: invalid escape", line, col)
+	}
+	ch := s.next()
+	switch ch {
+	case 'a':
+		return '\a', nil
+	case 'b':
+		return '\b', nil
+	case 'f':
+		return '\f', nil
+	case 'n':
+		return '\n', nil
+	case 'r':
+		return '\r', nil
+	case 't':
+		return '\t', nil
+	case 'v':
+		return '\v', nil
+	case '\\', '\'', '"', '?':
+		return rune(ch), nil
+	case 'x', 'u', 'U':
+		max := 2
+		if ch == 'u' {
+			max = 4
+		} else if ch == 'U' {
+			max = 8
+		}
+		var v rune
+		var n int
+		for ; n < max && !s.eof() && isXDigit(s.peek()); n++ {
+			v = v<<4 | rune(hexValue(s.next()))
+		}
+		if n == 0 || (ch != 'x' && n != max) {
+			return 0, fmt.Errorf("%d:%d: invalid \\%c escape", line, col, ch)
+		}
+		if ch == 'x' {
+			return rawByte(v), nil
+		}
+		if !utf8.ValidRune(v) {
+			return 0, fmt.Errorf("%d:%d: invalid code point %U", line, col, v)
+		}
+		return v, nil
+	case '0', '1', '2', '3', '4', '5', '6', '7':
+		v := rune(ch - '0')
+		for n := 1; n < 3 && !s.eof() && '0' <= s.peek() && s.peek() <= '7'; n++ {
+			v = v<<3 | rune(s.next()-'0')
+		}
+		if v > 0xff {
+			return 0, fmt.Errorf("%d:%d: octal escape out of range", line, col)
+		}
+		return rawByte(v), nil
+	default:
+		return 0, fmt.Errorf("%d:%d: unknown escape \\%c", line, col, ch)
+	}
+}
+
+// rawByte marks the byte values of \x and octal escapes so that
+// appendRune does not encode them as UTF-8. The marked values are
+// negative and thus never valid code points.
+func rawByte(v rune) rune {
+	return -1 - v
+}
+
+// appendRune appends r to buf. The raw byte values are appended as
+// is and all other runes as UTF-8.
+func appendRune(buf []byte, r rune) []byte {
+	if r < 0 {
+		return append(buf, byte(-1-r))
+	}
+	if r < utf8.RuneSelf {
+		return append(buf, byte(r))
+	}
+	var tmp [utf8.UTFMax]byte
+	n := utf8.EncodeRune(tmp[:], r)
+	return append(buf, tmp[:n]...)
+}
+
+func (s *scanner) parseHex() ([]byte, error) {
+	result := []byte{}
+	var hi byte
+	var odd bool
+	for {
+		if err := s.skipSpace(true); err != nil {
+			return nil, err
+		}
+		if s.eof() {
+			break
+		}
+		if !isXDigit(s.peek()) {
+			return nil, s.errorf("invalid hex digit %q", s.peek())
+		}
+		v := hexValue(s.next())
+		if odd {
+			result = append(result, hi<<4|v)
+		} else {
+			hi = v
+		}
+		odd = !odd
+	}
+	if odd {
+		return nil, s.errorf("odd number of hex digits")
+	}
+	return result, nil
+}
+
+func isSpace(ch byte) bool {
+	return ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' ||
+		ch == '\v' || ch == '\f'
+}
+
+func isElement(ch byte) bool {
+	return '0' <= ch && ch <= '9' || 'a' <= ch && ch <= 'z' ||
+		'A' <= ch && ch <= 'Z' || ch == '_' || ch == '-' || ch == '+'
+}
+
+func isSuffix(ch byte) bool {
+	return ch == 'u' || ch == 'U' || ch == 'l' || ch == 'L'
+}
+
+func isXDigit(ch byte) bool {
+	return '0' <= ch && ch <= '9' || 'a' <= ch && ch <= 'f' ||
+		'A' <= ch && ch <= 'F'
+}
+
+func hexValue(ch byte) byte {
+	switch {
+	case '0' <= ch && ch <= '9':
+		return ch - '0'
+	case 'a' <= ch && ch <= 'f':
+		return ch - 'a' + 10
+	default:
+		return ch - 'A' + 10
+	}
+}
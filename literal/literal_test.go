@@ -0,0 +1,171 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package literal
+
+import (
+	"bytes"
+	"testing"
+)
+
+var parseTests = []struct {
+	input  string
+	format Format
+	output string
+}{
+	{
+		input: `/* hello.txt */
+unsigned char hello_txt[] = {
+  0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x2c, 0x20, 0x77, 0x6f, 0x72, 0x6c, 0x64,
+  0x21, 0x0a // newline
+};
+unsigned int hello_txt_len = 14;
+`,
+		format: FormatC,
+		output: "Hello, world!\n",
+	},
+	{
+		input:  `static const signed char x[] = { -1, 0101, 65u, 'a', '\n', '\x7f', };`,
+		format: FormatC,
+		output: "\xffAAa\n\x7f",
+	},
+	{
+		input: `var data = []byte{
+	0x48, 0b1101001, // Hi
+	0o41, 0X0A,
+}`,
+		format: FormatGo,
+		output: "Hi!\n",
+	},
+	{
+		input:  `[]byte{}`,
+		format: FormatGo,
+		output: "",
+	},
+	{
+		input: `"\x48\x69" /* comment "x" */
+  "\041\n" + "é"`,
+		format: FormatEscaped,
+		output: "Hi!\né",
+	},
+	{
+		input:  "\\x48\\x69\\t\n\\x21\n",
+		format: FormatEscaped,
+		output: "Hi\t!",
+	},
+	{
+		input: `# header
+48 65 6c
+6C 6f  // hello
+`,
+		format: FormatHex,
+		output: "Hello",
+	},
+	{
+		input: `# 0x{ "header" }
+4869 // it's 0x21
+21 /* '{' */
+`,
+		format: FormatHex,
+		output: "Hi!",
+	},
+	{
+		input:  `"{\x48\x69}" // }`,
+		format: FormatEscaped,
+		output: "{Hi}",
+	},
+	{
+		input:  `char x[] = { '"', '\\', '{' };`,
+		format: FormatC,
+		output: "\"\\{",
+	},
+	{
+		input:  "",
+		format: FormatHex,
+		output: "",
+	},
+	{
+		input:  "  0x48, 0x69,\n  0x21\n",
+		format: FormatC,
+		output: "Hi!",
+	},
+}
+
+func TestParse(t *testing.T) {
+	for idx, test := range parseTests {
+		if f := Detect([]byte(test.input)); f != test.format {
+			t.Errorf("test %d: Detect=%s, expected %s", idx, f, test.format)
+		}
+		data, err := Parse([]byte(test.input))
+		if err != nil {
+			t.Errorf("test %d: Parse failed: %v", idx, err)
+			continue
+		}
+		if !bytes.Equal(data, []byte(test.output)) {
+			t.Errorf("test %d: got %q, expected %q", idx, data, test.output)
+		}
+	}
+}
+
+var errorTests = []struct {
+	input  string
+	format Format
+	err    string
+}{
+	{
+		input:  "{ 0x41,",
+		format: FormatC,
+		err:    "1:8: missing '}'",
+	},
+	{
+		input:  "{ 0x41,\n  0x100 }",
+		format: FormatC,
+		err:    `2:3: invalid byte value "0x100"`,
+	},
+	{
+		input:  "{ 0x41 0x42 }",
+		format: FormatGo,
+		err:    `1:8: unexpected '0'`,
+	},
+	{
+		input:  "{ 0x41, /* 0x42 }",
+		format: FormatC,
+		err:    "1:18: unterminated comment",
+	},
+	{
+		input:  `"\x41\q"`,
+		format: FormatEscaped,
+		err:    `1:6: unknown escape \q`,
+	},
+	{
+		input:  `"\x41`,
+		format: FormatEscaped,
+		err:    "1:6: unterminated string",
+	},
+	{
+		input:  "4142\n43g",
+		format: FormatHex,
+		err:    "2:3: invalid hex digit 'g'",
+	},
+	{
+		input:  "414",
+		format: FormatHex,
+		err:    "1:4: odd number of hex digits",
+	},
+}
+
+func TestParseErrors(t *testing.T) {
+	for idx, test := range errorTests {
+		_, err := ParseFormat([]byte(test.input), test.format)
+		if err == nil {
+			t.Errorf("test %d: expected error %q", idx, test.err)
+			continue
+		}
+		if err.Error() != test.err {
+			t.Errorf("test %d: got error %q, expected %q", idx, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package text
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"unicode"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// Faces define the font faces for the text styles. The Bold, Oblique,
+// and BoldOblique faces are optional. A missing BoldOblique face
+// defaults to Bold, and all other missing faces default to Regular.
+type Faces struct {
+	Regular     font.Face
+	Bold        font.Face
+	Oblique     font.Face
+	BoldOblique font.Face
+}
+
+func (faces *Faces) face(span *Span) font.Face {
+	var f font.Face
+	switch {
+	case span.Bold && span.Oblique:
+		f = faces.BoldOblique
+		if f == nil {
+			f = faces.Bold
+		}
+	case span.Bold:
+		f = faces.Bold
+	case span.Oblique:
+		f = faces.Oblique
+	}
+	if f == nil {
+		f = faces.Regular
+	}
+	return f
+}
+
+// Renderer draws text into images.
+type Renderer struct {
+	// Faces define the font faces for text styles.
+	Faces Faces
+	// Color specifies the default text color. The nil value draws
+	// text in black.
+	Color color.Color
+	// Width specifies the maximum line width in pixels. The text is
+	// wrapped at spaces to lines that fit into width. Words longer
+	// than width are broken at character boundaries. The zero value
+	// disables wrapping.
+	Width int
+	// LineHeight specifies the distance between consecutive
+	// baselines. The zero value uses the height of the Regular face.
+	LineHeight fixed.Int26_6
+}
+
+// NewRenderer creates a new renderer for the font face regular.
+func NewRenderer(regular font.Face) *Renderer {
+	return &Renderer{
+		Faces: Faces{
+			Regular: regular,
+		},
+	}
+}
+
+type glyph struct {
+	r    rune
+	span *Span
+	face font.Face
+	kern fixed.Int26_6
+	adv  fixed.Int26_6
+}
+
+// glyphs flattens the text into a sequence of glyphs. The kern of a
+// glyph is the kerning adjustment with the previous glyph, and it is
+// applied before the glyph is drawn. The kern is ignored at the
+// beginning of lines.
+func (r *Renderer) glyphs(text *Text) []glyph {
+	var result []glyph
+	r.flatten(text, &result)
+
+	for i := range result {
+		g := &result[i]
+		adv, ok := g.face.GlyphAdvance(g.r)
+		if !ok {
+			adv, _ = g.face.GlyphAdvance(unicode.ReplacementChar)
+		}
+		if i > 0 && result[i-1].face == g.face && result[i-1].r != '\n' {
+			g.kern = g.face.Kern(result[i-1].r, g.r)
+		}
+		g.adv = adv
+	}
+	return result
+}
+
+func (r *Renderer) flatten(text *Text, result *[]glyph) {
+	for i := range text.Spans {
+		span := &text.Spans[i]
+		if span.Link != nil {
+			r.flatten(span.Link, result)
+			continue
+		}
+		face := r.Faces.face(span)
+		for _, ch := range span.Content {
+			*result = append(*result, glyph{
+				r:    ch,
+				span: span,
+				face: face,
+			})
+		}
+	}
+}
+
+// lines wraps glyphs into lines.
+func (r *Renderer) lines(glyphs []glyph) [][]glyph {
+	var result [][]glyph
+
+	width := fixed.I(r.Width)
+	start := 0
+	for start < len(glyphs) {
+		var x fixed.Int26_6
+		brk := -1
+		end := start
+		for ; end < len(glyphs); end++ {
+			g := glyphs[end]
+			if g.r == '\n' {
+				break
+			}
+			if end > start {
+				x += g.kern
+			}
+			if r.Width > 0 && x+g.adv > width && end > start &&
+				!unicode.IsSpace(g.r) {
+				if brk > start {
+					end = brk
+				}
+				break
+			}
+			x += g.adv
+			if unicode.IsSpace(g.r) {
+				brk = end + 1
+			}
+		}
+		result = append(result, trimSpace(glyphs[start:end]))
+
+		if end < len(glyphs) && glyphs[end].r == '\n' {
+			end++
+			if end == len(glyphs) {
+				result = append(result, nil)
+			}
+		} else if r.Width > 0 {
+			for end < len(glyphs) && glyphs[end].r != '\n' &&
+				unicode.IsSpace(glyphs[end].r) {
+				end++
+			}
+		}
+		start = end
+	}
+
+	return result
+}
+
+func trimSpace(line []glyph) []glyph {
+	for len(line) > 0 && unicode.IsSpace(line[len(line)-1].r) {
+		line = line[:len(line)-1]
+	}
+	return line
+}
+
+func (r *Renderer) lineHeight() fixed.Int26_6 {
+	if r.LineHeight != 0 {
+		return r.LineHeight
+	}
+	return r.Faces.Regular.Metrics().Height
+}
+
+// Advance returns the advance width of the text when drawn on a
+// single line without wrapping.
+func (r *Renderer) Advance(text *Text) fixed.Int26_6 {
+	var advance fixed.Int26_6
+	for _, g := range r.glyphs(text) {
+		if g.r == '\n' {
+			continue
+		}
+		advance += g.kern + g.adv
+	}
+	return advance
+}
+
+// Bounds returns the bounding box of the text when drawn at the
+// origin. The origin is the baseline of the first line. The text is
+// wrapped according to the Width option.
+func (r *Renderer) Bounds(text *Text) fixed.Rectangle26_6 {
+	var bounds fixed.Rectangle26_6
+	var dot fixed.Point26_6
+
+	for _, line := range r.lines(r.glyphs(text)) {
+		dot.X = 0
+		for i, g := range line {
+			if i > 0 {
+				dot.X += g.kern
+			}
+			b, _, ok := g.face.GlyphBounds(g.r)
+			if ok && !b.Empty() {
+				bounds = bounds.Union(b.Add(dot))
+			}
+			dot.X += g.adv
+		}
+		dot.Y += r.lineHeight()
+	}
+	return bounds
+}
+
+// Draw draws the text into dst. The argument dot specifies the
+// baseline origin of the first line. The function returns the dot
+// position after the last drawn glyph.
+func (r *Renderer) Draw(dst draw.Image, dot fixed.Point26_6,
+	text *Text) fixed.Point26_6 {
+
+	fg := r.Color
+	if fg == nil {
+		fg = color.Black
+	}
+	left := dot.X
+
+	for idx, line := range r.lines(r.glyphs(text)) {
+		if idx > 0 {
+			dot.X = left
+			dot.Y += r.lineHeight()
+		}
+		for i, g := range line {
+			if i > 0 {
+				dot.X += g.kern
+			}
+			if g.span.BG != nil {
+				m := g.face.Metrics()
+				rect := image.Rect(dot.X.Floor(), (dot.Y - m.Ascent).Floor(),
+					(dot.X + g.adv).Ceil(), (dot.Y + m.Descent).Ceil())
+				draw.Draw(dst, rect, image.NewUniform(g.span.BG),
+					image.Point{}, draw.Over)
+			}
+			src := fg
+			if g.span.FG != nil {
+				src = g.span.FG
+			}
+			dr, mask, maskp, _, ok := g.face.Glyph(dot, g.r)
+			if !ok {
+				dr, mask, maskp, _, ok = g.face.Glyph(dot,
+					unicode.ReplacementChar)
+			}
+			if ok {
+				draw.DrawMask(dst, dr, image.NewUniform(src), image.Point{},
+					mask, maskp, draw.Over)
+			}
+			dot.X += g.adv
+		}
+	}
+	return dot
+}
@@ -0,0 +1,137 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package text
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+func TestRendererAdvance(t *testing.T) {
+	r := NewRenderer(basicfont.Face7x13)
+
+	adv := r.Advance(New().Plain("Hello, ").Bold("world!"))
+	if adv != fixed.I(13*7) {
+		t.Errorf("Advance: got %v, expected %v", adv, fixed.I(13*7))
+	}
+}
+
+func TestRendererWrap(t *testing.T) {
+	r := NewRenderer(basicfont.Face7x13)
+	r.Width = 7 * 8
+
+	txt := New().Plain("Hello, ").Oblique("big world!")
+
+	lines := r.lines(r.glyphs(txt))
+	expected := []string{"Hello,", "big", "world!"}
+	if len(lines) != len(expected) {
+		t.Fatalf("wrap: got %v lines, expected %v", len(lines), len(expected))
+	}
+	for idx, line := range lines {
+		var str string
+		for _, g := range line {
+			str += string(g.r)
+		}
+		if str != expected[idx] {
+			t.Errorf("line %d: got %q, expected %q", idx, str, expected[idx])
+		}
+	}
+
+	b := r.Bounds(txt)
+	if b.Max.X > fixed.I(r.Width) {
+		t.Errorf("Bounds: width %v exceeds %v", b.Max.X, r.Width)
+	}
+	m := basicfont.Face7x13.Metrics()
+	if b.Max.Y < 2*m.Height {
+		t.Errorf("Bounds: height %v too small for 3 lines", b.Max.Y)
+	}
+}
+
+func TestRendererDraw(t *testing.T) {
+	r := NewRenderer(basicfont.Face7x13)
+	img := image.NewRGBA(image.Rect(0, 0, 100, 20))
+
+	red := color.RGBA{R: 0xff, A: 0xff}
+	txt := New().AppendSpan(Span{
+		FG:      red,
+		Content: "ab",
+	})
+	dot := r.Draw(img, fixed.P(0, 13), txt)
+	if dot.X != fixed.I(14) {
+		t.Errorf("Draw: got dot %v, expected %v", dot.X, fixed.I(14))
+	}
+
+	var count int
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 100; x++ {
+			if img.RGBAAt(x, y) == red {
+				count++
+			}
+		}
+	}
+	if count == 0 {
+		t.Errorf("Draw: no pixels drawn")
+	}
+}
+
+// kernFace is a test face that kerns the pair "AV" by -2 pixels.
+type kernFace struct {
+	font.Face
+}
+
+func (f kernFace) Kern(r0, r1 rune) fixed.Int26_6 {
+	if r0 == 'A' && r1 == 'V' {
+		return -fixed.I(2)
+	}
+	return 0
+}
+
+func TestRendererKern(t *testing.T) {
+	r := NewRenderer(kernFace{basicfont.Face7x13})
+
+	if adv := r.Advance(New().Plain("AV")); adv != fixed.I(12) {
+		t.Errorf("Advance: got %v, expected %v", adv, fixed.I(12))
+	}
+
+	vb, _, _ := basicfont.Face7x13.GlyphBounds('V')
+	b := r.Bounds(New().Plain("AV"))
+	if b.Max.X != vb.Max.X+fixed.I(5) {
+		t.Errorf("Bounds: got max %v, expected %v", b.Max.X,
+			vb.Max.X+fixed.I(5))
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, 20, 20))
+	dot := r.Draw(img, fixed.P(0, 13), New().Plain("AV"))
+	if dot.X != fixed.I(12) {
+		t.Errorf("Draw: got dot %v, expected %v", dot.X, fixed.I(12))
+	}
+
+	// The V glyph must be drawn at the kerned position 5.
+	expected := image.NewRGBA(image.Rect(0, 0, 20, 20))
+	plain := NewRenderer(basicfont.Face7x13)
+	plain.Draw(expected, fixed.P(0, 13), New().Plain("A"))
+	plain.Draw(expected, fixed.P(5, 13), New().Plain("V"))
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 20; x++ {
+			if img.RGBAAt(x, y) != expected.RGBAAt(x, y) {
+				t.Fatalf("Draw: pixel %d,%d differs", x, y)
+			}
+		}
+	}
+
+	// The kern does not apply at the beginning of a wrapped line.
+	r.Width = 7
+	b = r.Bounds(New().Plain("AV"))
+	if b.Min.X != vb.Min.X {
+		t.Errorf("Bounds: got min %v, expected %v", b.Min.X, vb.Min.X)
+	}
+}
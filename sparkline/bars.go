@@ -0,0 +1,187 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package sparkline
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strings"
+
+	"github.com/markkurossi/text"
+)
+
+// leftBlocks define the left aligned partial blocks of 1/8...7/8
+// cell width.
+var leftBlocks = []rune{
+	0x258F, 0x258E, 0x258D, 0x258C, 0x258B, 0x258A, 0x2589,
+}
+
+// rightBlocks define the right aligned partial blocks of 1/8...7/8
+// cell width. The Block Elements block has only the 1/8 and 1/2
+// right blocks so the widths are quantized to the nearest available
+// block.
+var rightBlocks = []rune{
+	0x2595, 0x2595, 0x2590, 0x2590, 0x2590, 0x2590, 0x2588,
+}
+
+// legacyRightBlocks define the right aligned partial blocks of
+// 1/8...7/8 cell width. The blocks 1/4, 3/8, 5/8, 3/4, and 7/8 are
+// from the Symbols for Legacy Computing block.
+var legacyRightBlocks = []rune{
+	0x2595, 0x1FB87, 0x1FB88, 0x2590, 0x1FB89, 0x1FB8A, 0x1FB8B,
+}
+
+// Bar defines a labeled value of a bar chart.
+type Bar struct {
+	Label string
+	Value float64
+	// Color specifies an optional color for the bar in the Text
+	// output.
+	Color color.Color
+}
+
+// Bars defines a horizontal bar chart. Each chart line has a left
+// aligned label, the bar, and a right aligned value. All bars use
+// the same scale. Positive values extend right and negative values
+// extend left from the zero axis. The NaN and infinite values are
+// shown without bars.
+type Bars struct {
+	// Width specifies the width of the chart lines in terminal
+	// cells. The zero value uses the width 80.
+	Width int
+	// Format specifies the fmt verb for formatting the values. The
+	// empty value uses "%g".
+	Format string
+	// Legacy specifies if the negative bars use the right partial
+	// blocks of the Symbols for Legacy Computing block. The blocks
+	// render the negative bars in eighth cell resolution but most
+	// terminal fonts do not have them. The zero value quantizes the
+	// negative bars to the 1/8, 1/2, and full blocks.
+	Legacy bool
+}
+
+// New creates a bar chart of bars. The function returns the chart
+// lines.
+func (b *Bars) New(bars []Bar) []string {
+	var result []string
+	for _, line := range b.Text(bars) {
+		result = append(result, line.String())
+	}
+	return result
+}
+
+// Text creates a bar chart of bars. The function returns the chart
+// lines as text objects where the labels, bars, and values are in
+// separate spans.
+func (b *Bars) Text(bars []Bar) []*text.Text {
+	width := b.Width
+	if width <= 0 {
+		width = 80
+	}
+	format := b.Format
+	if len(format) == 0 {
+		format = "%g"
+	}
+
+	var labelWidth, valueWidth int
+	var neg, pos float64
+	values := make([]string, len(bars))
+	for i, bar := range bars {
+		w := text.StringWidth(bar.Label)
+		if w > labelWidth {
+			labelWidth = w
+		}
+		values[i] = fmt.Sprintf(format, bar.Value)
+		w = text.StringWidth(values[i])
+		if w > valueWidth {
+			valueWidth = w
+		}
+		if !finite(bar.Value) {
+			continue
+		}
+		if bar.Value < neg {
+			neg = bar.Value
+		}
+		if bar.Value > pos {
+			pos = bar.Value
+		}
+	}
+	neg = -neg
+
+	area := width - labelWidth - valueWidth - 2
+	if area < 1 {
+		area = 1
+	}
+	var negArea int
+	if neg+pos > 0 {
+		negArea = int(math.Round(float64(area) * neg / (neg + pos)))
+	}
+	posArea := area - negArea
+
+	var result []*text.Text
+	for i, bar := range bars {
+		line := text.New().Plain(bar.Label).PadEnd(labelWidth).Plain(" ")
+
+		var eighths int
+		if neg+pos > 0 && finite(bar.Value) {
+			eighths = int(math.Round(math.Abs(bar.Value) * float64(area) * 8 /
+				(neg + pos)))
+		}
+		var content string
+		if bar.Value < 0 {
+			if eighths > negArea*8 {
+				eighths = negArea * 8
+			}
+			partials := rightBlocks
+			if b.Legacy {
+				partials = legacyRightBlocks
+			}
+			content = barContent(eighths, partials, true)
+			line.Plain(strings.Repeat(" ", negArea-text.StringWidth(content)))
+			line.AppendSpan(text.Span{
+				FG:      bar.Color,
+				Content: content,
+			})
+			line.Plain(strings.Repeat(" ", posArea))
+		} else {
+			if eighths > posArea*8 {
+				eighths = posArea * 8
+			}
+			content = barContent(eighths, leftBlocks, false)
+			line.Plain(strings.Repeat(" ", negArea))
+			line.AppendSpan(text.Span{
+				FG:      bar.Color,
+				Content: content,
+			})
+			line.Plain(strings.Repeat(" ", posArea-text.StringWidth(content)))
+		}
+
+		line.Plain(" ").Append(text.New().Plain(values[i]).PadStart(valueWidth))
+		result = append(result, line)
+	}
+	return result
+}
+
+func finite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// barContent creates a bar of eighths eighth blocks. The partial
+// block is taken from the partials array and it is placed at the
+// beginning of the bar if leading is true.
+func barContent(eighths int, partials []rune, leading bool) string {
+	full := strings.Repeat("█", eighths/8)
+	if eighths%8 == 0 {
+		return full
+	}
+	partial := string(partials[eighths%8-1])
+	if leading {
+		return partial + full
+	}
+	return full + partial
+}
@@ -0,0 +1,115 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package sparkline
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+var barsTests = []struct {
+	width int
+	bars  []Bar
+	lines []string
+}{
+	{
+		width: 16,
+		bars: []Bar{
+			{Label: "a", Value: 8},
+			{Label: "bbb", Value: 4},
+			{Label: "cc", Value: 0.5},
+		},
+		lines: []string{
+			"a   ████████   8",
+			"bbb ████       4",
+			"cc  ▌        0.5",
+		},
+	},
+	{
+		width: 14,
+		bars: []Bar{
+			{Label: "up", Value: 2},
+			{Label: "down", Value: -2},
+			{Label: "flat", Value: 0},
+			{Label: "half", Value: -0.5},
+		},
+		lines: []string{
+			"up     ██    2",
+			"down ██     -2",
+			"flat         0",
+			"half  ▐   -0.5",
+		},
+	},
+	{
+		width: 16,
+		bars: []Bar{
+			{Label: "a", Value: 2},
+			{Label: "inf", Value: math.Inf(1)},
+			{Label: "-inf", Value: math.Inf(-1)},
+			{Label: "nan", Value: math.NaN()},
+			{Label: "b", Value: -2},
+		},
+		lines: []string{
+			"a       ███    2",
+			"inf         +Inf",
+			"-inf        -Inf",
+			"nan          NaN",
+			"b    ███      -2",
+		},
+	},
+}
+
+func TestBars(t *testing.T) {
+	for idx, test := range barsTests {
+		b := &Bars{
+			Width: test.width,
+		}
+		lines := b.New(test.bars)
+		if len(lines) != len(test.lines) {
+			t.Fatalf("%d: got %v lines, expected %v",
+				idx, len(lines), len(test.lines))
+		}
+		for i, line := range lines {
+			if line != test.lines[i] {
+				t.Errorf("%d: line %d: got %q, expected %q",
+					idx, i, line, test.lines[i])
+			}
+		}
+	}
+}
+
+func TestBarsText(t *testing.T) {
+	b := &Bars{
+		Width: 14,
+	}
+	red := color.NRGBA{R: 0xff, A: 0xff}
+	lines := b.Text([]Bar{{Label: "x", Value: 1, Color: red}})
+	expected := "x \x1b[38;2;255;0;0m██████████\x1b[0m 1"
+	if ansi := lines[0].ANSI(); ansi != expected {
+		t.Errorf("got %q, expected %q", ansi, expected)
+	}
+}
+
+func TestBarsLegacy(t *testing.T) {
+	bars := []Bar{
+		{Label: "a", Value: -8},
+		{Label: "b", Value: -3},
+	}
+	b := &Bars{
+		Width: 14,
+	}
+	checkLines(t, b.New(bars), []string{
+		"a █████████ -8",
+		"b      ▐███ -3",
+	})
+	b.Legacy = true
+	checkLines(t, b.New(bars), []string{
+		"a █████████ -8",
+		"b      \U0001FB88███ -3",
+	})
+}
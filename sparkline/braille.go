@@ -0,0 +1,153 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package sparkline
+
+import (
+	"fmt"
+)
+
+// BrailleMode defines how values are plotted in Braille charts.
+type BrailleMode int
+
+// Braille chart modes.
+const (
+	// BrailleLine connects consecutive values with lines.
+	BrailleLine BrailleMode = iota
+	// BrailleScatter plots values as individual dots.
+	BrailleScatter
+)
+
+var brailleModes = map[BrailleMode]string{
+	BrailleLine:    "line",
+	BrailleScatter: "scatter",
+}
+
+func (m BrailleMode) String() string {
+	name, ok := brailleModes[m]
+	if ok {
+		return name
+	}
+	return fmt.Sprintf("{BrailleMode %d}", m)
+}
+
+// brailleDots maps the dot coordinates [x][y] of a Braille cell to
+// the pattern bits of the Braille Patterns block (u2800-u28FF). The
+// y coordinate grows downwards.
+var brailleDots = [2][4]rune{
+	{0x01, 0x02, 0x04, 0x40},
+	{0x08, 0x10, 0x20, 0x80},
+}
+
+// Braille defines a line chart that is plotted with Braille
+// patterns. Each character cell has 2×4 dots so each cell holds two
+// values and has four vertical levels per row.
+type Braille struct {
+	// Rows specifies the number of rows in the chart. Values smaller
+	// than 1 render a single row.
+	Rows int
+	// Mode specifies the plotting mode.
+	Mode BrailleMode
+	// Scale specifies the chart scale.
+	Scale Scale
+}
+
+// New creates a Braille chart of values. The chart is scaled to the
+// [min...max] of the finite values in the values array.
+func (b *Braille) New(values []float64) []string {
+	min, max := Bounds(values)
+	return b.Range(min, max, values)
+}
+
+// Range creates a Braille chart of values. The chart is scaled to
+// [min...max]. The function returns the chart rows from top to
+// bottom. Values outside the range and NaN values are not plotted,
+// and they break the line in the BrailleLine mode.
+func (b *Braille) Range(min, max float64, values []float64) []string {
+	rows := b.Rows
+	if rows < 1 {
+		rows = 1
+	}
+	if max < min {
+		min = max
+	}
+	scale, min := b.Scale.lower(min, values)
+	height := rows * 4
+	width := (len(values) + 1) / 2
+
+	cells := make([][]rune, rows)
+	for row := range cells {
+		cells[row] = make([]rune, width)
+	}
+	set := func(x, y int) {
+		// Flip y so that it grows downwards.
+		y = height - 1 - y
+		cells[y/4][x/2] |= brailleDots[x%2][y%4]
+	}
+
+	prev := -1
+	for x, v := range values {
+		pos, cls := scale.classify(min, max, v)
+		if cls != inRange {
+			prev = -1
+			continue
+		}
+		y := level(pos, height-1)
+		if b.Mode == BrailleLine && prev >= 0 {
+			line(x-1, prev, x, y, set)
+		} else {
+			set(x, y)
+		}
+		prev = y
+	}
+
+	result := make([]string, rows)
+	for row, line := range cells {
+		for col := range line {
+			line[col] += 0x2800
+		}
+		result[row] = string(line)
+	}
+	return result
+}
+
+// line plots a line from (x0,y0) to (x1,y1) with Bresenham's
+// algorithm.
+func line(x0, y0, x1, y1 int, plot func(x, y int)) {
+	dx := x1 - x0
+	if dx < 0 {
+		dx = -dx
+	}
+	dy := y1 - y0
+	if dy < 0 {
+		dy = -dy
+	}
+	sx := 1
+	if x0 > x1 {
+		sx = -1
+	}
+	sy := 1
+	if y0 > y1 {
+		sy = -1
+	}
+	err := dx - dy
+
+	for {
+		plot(x0, y0)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 > -dy {
+			err -= dy
+			x0 += sx
+		}
+		if e2 < dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
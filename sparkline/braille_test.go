@@ -0,0 +1,46 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package sparkline
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBrailleScatter(t *testing.T) {
+	b := &Braille{
+		Mode: BrailleScatter,
+	}
+	lines := b.Range(0, 3, []float64{0, 1, 2, 3, math.NaN()})
+	expected := []string{"⡠⠊⠀"}
+	if len(lines) != 1 || lines[0] != expected[0] {
+		t.Errorf("got %q, expected %q", lines, expected)
+	}
+}
+
+func TestBrailleLine(t *testing.T) {
+	b := &Braille{}
+	lines := b.Range(0, 3, []float64{0, 3})
+	expected := "⡜"
+	if len(lines) != 1 || lines[0] != expected {
+		t.Errorf("got %q, expected %q", lines, expected)
+	}
+
+	b = &Braille{
+		Rows: 3,
+	}
+	var values []float64
+	for i := 0; i < 60; i++ {
+		values = append(values, math.Sin(float64(i)/5))
+	}
+	lines = b.New(values)
+	checkLines(t, lines, []string{
+		"⠀⢀⠔⠒⠑⠢⢄⠀⠀⠀⠀⠀⠀⠀⠀⠀⠀⡠⠔⠒⠒⠢⡀⠀⠀⠀⠀⠀⠀⠀",
+		"⠔⠁⠀⠀⠀⠀⠀⠑⢄⠀⠀⠀⠀⠀⠀⡠⠊⠀⠀⠀⠀⠀⠈⠢⡀⠀⠀⠀⠀⠀",
+		"⠀⠀⠀⠀⠀⠀⠀⠀⠀⠑⢄⣀⣀⡠⠒⠁⠀⠀⠀⠀⠀⠀⠀⠀⠈⠢⣀⣀⣀⡠",
+	})
+}
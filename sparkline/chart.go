@@ -0,0 +1,122 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package sparkline
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Chart defines a multi-row histogram chart. Each column of the chart
+// is built from full blocks and a partial block on top of them,
+// giving 8×Rows vertical levels.
+type Chart struct {
+	// Rows specifies the number of rows in the chart. Values smaller
+	// than 1 render a single row.
+	Rows int
+	// Labels specifies if the chart range labels are rendered. The
+	// max label is rendered on the left side of the top row and the
+	// min label on the left side of the bottom row.
+	Labels bool
+	// Format specifies the fmt verb for formatting the labels. The
+	// empty value uses "%g".
+	Format string
+	// Scale specifies the chart scale.
+	Scale Scale
+}
+
+// New creates a chart of values. The chart is scaled to the
+// [min...max] of the finite values in the values array.
+func (c *Chart) New(values []float64) []string {
+	min, max := Bounds(values)
+	return c.Range(min, max, values)
+}
+
+// Range creates a chart of values. The chart is scaled to
+// [min...max]. The function returns the chart rows from top to
+// bottom. Values smaller than min and NaN values are rendered as
+// empty columns. Values larger than max are rendered as columns of
+// Above runes.
+func (c *Chart) Range(min, max float64, values []float64) []string {
+	rows := c.Rows
+	if rows < 1 {
+		rows = 1
+	}
+	if max < min {
+		min = max
+	}
+	scale, min := c.Scale.lower(min, values)
+	levels := rows * 8
+
+	// Column heights in eighth blocks; -1 marks values above max.
+	heights := make([]int, len(values))
+	for i, v := range values {
+		pos, cls := scale.classify(min, max, v)
+		switch cls {
+		case inRange:
+			if min == max {
+				heights[i] = levels/2 + 1
+			} else {
+				heights[i] = 1 + level(pos, levels-1)
+			}
+		case above:
+			heights[i] = -1
+		}
+	}
+
+	var labelMin, labelMax string
+	var labelWidth int
+	if c.Labels {
+		format := c.Format
+		if len(format) == 0 {
+			format = "%g"
+		}
+		labelMin = fmt.Sprintf(format, min)
+		labelMax = fmt.Sprintf(format, max)
+		labelWidth = len(labelMin)
+		if len(labelMax) > labelWidth {
+			labelWidth = len(labelMax)
+		}
+	}
+
+	result := make([]string, rows)
+	for row := 0; row < rows; row++ {
+		var sb strings.Builder
+		if c.Labels {
+			var label string
+			if row == 0 {
+				label = labelMax
+			} else if row == rows-1 {
+				label = labelMin
+			}
+			fmt.Fprintf(&sb, "%*s ", labelWidth, label)
+		}
+		base := (rows - 1 - row) * 8
+		for _, h := range heights {
+			sb.WriteRune(block(h, base))
+		}
+		result[row] = sb.String()
+	}
+	return result
+}
+
+// block returns the block element for a column of height h eighths in
+// the row starting at base eighths.
+func block(h, base int) rune {
+	if h < 0 {
+		return Above
+	}
+	fill := h - base
+	switch {
+	case fill <= 0:
+		return ' '
+	case fill >= 8:
+		return 0x2588
+	default:
+		return rune(0x2580 + fill)
+	}
+}
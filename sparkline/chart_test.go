@@ -0,0 +1,59 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package sparkline
+
+import (
+	"math"
+	"testing"
+)
+
+func TestChartSingleRow(t *testing.T) {
+	values := []float64{83, 61, 33, 25, 12, 1, 0, 75}
+	c := &Chart{}
+
+	lines := c.New(values)
+	if len(lines) != 1 {
+		t.Fatalf("got %v rows, expected 1", len(lines))
+	}
+	if lines[0] != NewFloat(values) {
+		t.Errorf("got %q, expected %q", lines[0], NewFloat(values))
+	}
+}
+
+func TestChartRows(t *testing.T) {
+	c := &Chart{
+		Rows:   2,
+		Labels: true,
+	}
+	lines := c.Range(0, 15, []float64{0, 7, 8, 15, 16, -1, math.NaN()})
+	expected := []string{
+		"15   ▁█░  ",
+		" 0 ▁███░  ",
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("got %v rows, expected %v", len(lines), len(expected))
+	}
+	for idx, line := range lines {
+		if line != expected[idx] {
+			t.Errorf("row %d: got %q, expected %q", idx, line, expected[idx])
+		}
+	}
+
+	c = &Chart{
+		Rows:   4,
+		Labels: true,
+		Format: "%.1f",
+	}
+	lines = c.New([]float64{1, 2, 3, 5, 8, 13, 21, 34, 21, 13})
+	expected = []string{
+		"34.0        █  ",
+		"           ▃█▃ ",
+		"          ▄███▄",
+		" 1.0 ▁▁▂▄▇█████",
+	}
+	checkLines(t, lines, expected)
+}
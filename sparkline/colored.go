@@ -0,0 +1,110 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package sparkline
+
+import (
+	"image/color"
+
+	"github.com/markkurossi/text"
+	cs "github.com/markkurossi/text/color"
+)
+
+// Band defines a threshold color band. The band covers values
+// starting from From up to the From of the next band.
+type Band struct {
+	From  float64
+	Color color.Color
+}
+
+// Colored defines a histogram chart where each cell is colored by its
+// value. The colors are selected from threshold bands if they are
+// defined, and from the color scheme otherwise.
+type Colored struct {
+	// Scheme specifies a sequential color scheme. The cell color is
+	// selected by the cell value's relative position in the chart
+	// range. The missing values are rendered with the scheme's
+	// BadData background color.
+	Scheme *cs.Scheme
+	// Discrete specifies if the scheme colors are used as given. By
+	// default, the colors are linearly interpolated.
+	Discrete bool
+	// Bands specify threshold color bands in increasing From
+	// order. Values smaller than the first band are not colored.
+	Bands []Band
+}
+
+// New creates a colored histogram chart of values. The chart is
+// scaled to the [min...max] of the finite values in the values
+// array.
+func (c *Colored) New(values []float64) *text.Text {
+	min, max := Bounds(values)
+	return c.Range(min, max, values)
+}
+
+// Range creates a colored histogram chart of values. The chart is
+// scaled to [min...max]. The ticks are rendered as with the RangeFloat
+// function and the consecutive ticks with the same color are merged
+// into single spans. Use the text's ANSI method for rendering the
+// chart with 24-bit terminal colors.
+func (c *Colored) Range(min, max float64, values []float64) *text.Text {
+	result := text.New()
+	if max < min {
+		min = max
+	}
+
+	var span text.Span
+	for _, v := range values {
+		var fg, bg color.Color
+
+		pos, cls := classify(min, max, v)
+		switch cls {
+		case missing:
+			if c.Scheme != nil && c.Scheme.BadData != nil {
+				bg = c.Scheme.BadData.BG
+			}
+		case above:
+			pos = 1
+			fallthrough
+		case inRange:
+			fg = c.color(pos, v)
+		}
+
+		r := Linear.tick(min, max, v)
+		if len(span.Content) > 0 && (span.FG != fg || span.BG != bg) {
+			result.AppendSpan(span)
+			span = text.Span{}
+		}
+		span.FG = fg
+		span.BG = bg
+		span.Content += string(r)
+	}
+	if len(span.Content) > 0 {
+		result.AppendSpan(span)
+	}
+
+	return result
+}
+
+func (c *Colored) color(pos, v float64) color.Color {
+	if len(c.Bands) > 0 {
+		var result color.Color
+		for _, band := range c.Bands {
+			if v < band.From {
+				break
+			}
+			result = band.Color
+		}
+		return result
+	}
+	if c.Scheme == nil || len(c.Scheme.Colors) == 0 {
+		return nil
+	}
+	if c.Discrete {
+		return c.Scheme.Quantize(pos).BG
+	}
+	return c.Scheme.Interpolate(pos)
+}
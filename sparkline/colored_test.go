@@ -0,0 +1,98 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package sparkline
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	cs "github.com/markkurossi/text/color"
+)
+
+func TestColoredScheme(t *testing.T) {
+	c := &Colored{
+		Scheme: cs.YlOrBr,
+	}
+	values := []float64{0, 0, 8, math.NaN()}
+	txt := c.New(values)
+	if len(txt.Spans) != 3 {
+		t.Fatalf("got %v spans, expected 3", len(txt.Spans))
+	}
+	if txt.Spans[0].Content != "▁▁" {
+		t.Errorf("span 0: got %q, expected %q", txt.Spans[0].Content, "▁▁")
+	}
+	first := cs.YlOrBr.Colors[0].BG
+	if txt.Spans[0].FG != first {
+		t.Errorf("span 0: got color %v, expected %v", txt.Spans[0].FG, first)
+	}
+	last := cs.YlOrBr.Colors[len(cs.YlOrBr.Colors)-1].BG
+	if txt.Spans[1].FG != last {
+		t.Errorf("span 1: got color %v, expected %v", txt.Spans[1].FG, last)
+	}
+	bad := cs.YlOrBr.BadData.BG
+	if txt.Spans[2].BG != bad || txt.Spans[2].FG != nil {
+		t.Errorf("span 2: got color %v/%v, expected nil/%v",
+			txt.Spans[2].FG, txt.Spans[2].BG, bad)
+	}
+
+	c.Scheme = cs.Iridescent
+	var ramp []float64
+	for i := 0; i < 40; i++ {
+		ramp = append(ramp, float64(i))
+	}
+	txt = c.New(ramp)
+	if s, e := txt.String(), NewFloat(ramp); s != e {
+		t.Errorf("ramp: got %q, expected %q", s, e)
+	}
+	first = cs.Iridescent.Colors[0].BG
+	if fg := txt.Spans[0].FG; fg != first {
+		t.Errorf("ramp: got first color %v, expected %v", fg, first)
+	}
+	last = cs.Iridescent.Colors[len(cs.Iridescent.Colors)-1].BG
+	if fg := txt.Spans[len(txt.Spans)-1].FG; fg != last {
+		t.Errorf("ramp: got last color %v, expected %v", fg, last)
+	}
+	for i := 1; i < len(txt.Spans); i++ {
+		if txt.Spans[i].FG == txt.Spans[i-1].FG {
+			t.Errorf("ramp: spans %d and %d have the same color", i-1, i)
+		}
+	}
+}
+
+func TestColoredBands(t *testing.T) {
+	green := color.NRGBA{G: 0xff, A: 0xff}
+	yellow := color.NRGBA{R: 0xff, G: 0xff, A: 0xff}
+	red := color.NRGBA{R: 0xff, A: 0xff}
+
+	c := &Colored{
+		Bands: []Band{
+			{From: 0, Color: green},
+			{From: 50, Color: yellow},
+			{From: 80, Color: red},
+		},
+	}
+	txt := c.Range(0, 100, []float64{10, 20, 60, 90, 120})
+	expected := []struct {
+		content string
+		fg      color.Color
+	}{
+		{"▁▂", green},
+		{"▅", yellow},
+		{"▇░", red},
+	}
+	if len(txt.Spans) != len(expected) {
+		t.Fatalf("got %v spans, expected %v", len(txt.Spans), len(expected))
+	}
+	for idx, e := range expected {
+		span := txt.Spans[idx]
+		if span.Content != e.content || span.FG != e.fg {
+			t.Errorf("span %d: got %q/%v, expected %q/%v",
+				idx, span.Content, span.FG, e.content, e.fg)
+		}
+	}
+}
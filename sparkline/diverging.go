@@ -0,0 +1,159 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package sparkline
+
+import (
+	"math"
+	"strings"
+)
+
+// upperBlocks define the upper aligned partial blocks of 1/8...7/8
+// cell height. The Block Elements block has only the 1/8 and 1/2
+// upper blocks so the heights are quantized to the nearest available
+// block.
+var upperBlocks = []rune{
+	0x2594, 0x2594, 0x2580, 0x2580, 0x2580, 0x2580, 0x2588,
+}
+
+// legacyUpperBlocks define the upper aligned partial blocks of
+// 1/8...7/8 cell height. The blocks 1/4, 3/8, 5/8, 3/4, and 7/8 are
+// from the Symbols for Legacy Computing block.
+var legacyUpperBlocks = []rune{
+	0x2594, 0x1FB82, 0x1FB83, 0x2580, 0x1FB84, 0x1FB85, 0x1FB86,
+}
+
+// Diverging defines a chart that is centered on a baseline. The
+// chart has an upper half for values larger than the baseline and a
+// lower half for values smaller than the baseline. The positive
+// values are rendered with blocks that grow up from the baseline,
+// and the negative values with blocks that grow down from the
+// baseline.
+type Diverging struct {
+	// Rows specifies the number of rows in each half of the
+	// chart. Values smaller than 1 render one row per half.
+	Rows int
+	// Baseline specifies the chart baseline.
+	Baseline float64
+	// Legacy specifies if the lower half uses the upper partial
+	// blocks of the Symbols for Legacy Computing block. The blocks
+	// render the negative values in eighth block resolution but most
+	// terminal fonts do not have them. The zero value quantizes the
+	// negative values to the 1/8, 1/2, and full blocks.
+	Legacy bool
+}
+
+// New creates a diverging chart of values. The chart is scaled
+// symmetrically around the baseline so that it covers all finite
+// values in the values array.
+func (d *Diverging) New(values []float64) []string {
+	min, max := Bounds(values)
+	extent := math.Max(math.Abs(min-d.Baseline), math.Abs(max-d.Baseline))
+	return d.Range(d.Baseline-extent, d.Baseline+extent, values)
+}
+
+// Range creates a diverging chart of values. The upper half of the
+// chart is scaled to [Baseline...max] and the lower half to
+// [min...Baseline]. The function returns the chart rows from top to
+// bottom. The values outside the [min...max] range and the NaN
+// values are rendered as with the RangeFloat function.
+func (d *Diverging) Range(min, max float64, values []float64) []string {
+	rows := d.Rows
+	if rows < 1 {
+		rows = 1
+	}
+	if max < min {
+		min = max
+	}
+	levels := rows * 8
+	b := d.Baseline
+	partials := upperBlocks
+	if d.Legacy {
+		partials = legacyUpperBlocks
+	}
+
+	// Column heights in eighth blocks: positive heights grow up and
+	// negative heights grow down from the baseline.
+	heights := make([]int, len(values))
+	marks := make([]rune, len(values))
+	for i, v := range values {
+		_, cls := classify(min, max, v)
+		switch cls {
+		case missing:
+			marks[i] = Gap
+		case below:
+			marks[i] = Below
+		case above:
+			marks[i] = Above
+		default:
+			if v > b {
+				heights[i] = 1 + level((v-b)/(max-b), levels-1)
+			} else if v < b {
+				heights[i] = -1 - level((b-v)/(b-min), levels-1)
+			}
+		}
+	}
+
+	result := make([]string, 0, 2*rows)
+	for row := 0; row < rows; row++ {
+		var sb strings.Builder
+		base := (rows - 1 - row) * 8
+		for i, h := range heights {
+			if marks[i] == Above {
+				sb.WriteRune(Above)
+			} else if h > 0 {
+				sb.WriteRune(block(h, base))
+			} else {
+				sb.WriteRune(' ')
+			}
+		}
+		result = append(result, sb.String())
+	}
+	for row := 0; row < rows; row++ {
+		var sb strings.Builder
+		base := row * 8
+		for i, h := range heights {
+			fill := -h - base
+			switch {
+			case marks[i] == Below:
+				sb.WriteRune(Below)
+			case marks[i] == Gap:
+				sb.WriteRune(Gap)
+			case h >= 0 || fill <= 0:
+				sb.WriteRune(' ')
+			case fill >= 8:
+				sb.WriteRune(0x2588)
+			default:
+				sb.WriteRune(partials[fill-1])
+			}
+		}
+		result = append(result, sb.String())
+	}
+	return result
+}
+
+// WinLoss creates a win/loss chart of values. The values larger than
+// the baseline are rendered as wins with the upper half block
+// (u2580), the values smaller than the baseline as losses with the
+// lower half block (u2584), and the values equal to the baseline as
+// draws with the box drawings light horizontal (u2500). NaN values
+// are rendered with Gap.
+func WinLoss(baseline float64, values []float64) string {
+	var sb strings.Builder
+	for _, v := range values {
+		switch {
+		case math.IsNaN(v):
+			sb.WriteRune(Gap)
+		case v > baseline:
+			sb.WriteRune(0x2580)
+		case v < baseline:
+			sb.WriteRune(0x2584)
+		default:
+			sb.WriteRune(0x2500)
+		}
+	}
+	return sb.String()
+}
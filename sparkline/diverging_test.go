@@ -0,0 +1,78 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package sparkline
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDiverging(t *testing.T) {
+	d := &Diverging{}
+	lines := d.Range(-8, 8, []float64{8, 4, 0, -4, -8, 9, -9, math.NaN()})
+	expected := []string{
+		"█▄   ░  ",
+		"   ▀█ _ ",
+	}
+	checkLines(t, lines, expected)
+
+	d = &Diverging{
+		Rows:     2,
+		Baseline: 10,
+	}
+	lines = d.New([]float64{26, 18, 10, 2, 8})
+	expected = []string{
+		"█    ",
+		"██   ",
+		"   █▔",
+		"     ",
+	}
+	checkLines(t, lines, expected)
+
+	d.Legacy = true
+	lines = d.New([]float64{26, 18, 10, 2, 8})
+	expected[2] = "   █\U0001FB82"
+	checkLines(t, lines, expected)
+
+	d = &Diverging{
+		Rows: 3,
+	}
+	var values []float64
+	for i := 0; i < 40; i++ {
+		values = append(values, math.Sin(float64(i)/4)*float64(i))
+	}
+	lines = d.New(values)
+	expected = []string{
+		"                             ▂▅▇█▇▄     ",
+		"                           ▁▆███████▃   ",
+		" ▁▁▂▃▄▅▆▆▆▅▄▂             ▅██████████▅  ",
+		"             ▔▀█████████▀▔            ▀█",
+		"                ▔▀▀▀▀▀▀▔               ▔",
+		"                                        ",
+	}
+	checkLines(t, lines, expected)
+}
+
+func checkLines(t *testing.T, lines, expected []string) {
+	t.Helper()
+	if len(lines) != len(expected) {
+		t.Fatalf("got %v lines, expected %v", len(lines), len(expected))
+	}
+	for idx, line := range lines {
+		if line != expected[idx] {
+			t.Errorf("line %d: got %q, expected %q", idx, line, expected[idx])
+		}
+	}
+}
+
+func TestWinLoss(t *testing.T) {
+	result := WinLoss(0, []float64{1, -1, 0, 3, math.NaN(), -2})
+	expected := "▀▄─▀ ▄"
+	if result != expected {
+		t.Errorf("got %q, expected %q", result, expected)
+	}
+}
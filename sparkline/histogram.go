@@ -0,0 +1,190 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package sparkline
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// BinRule defines how the number and edges of histogram bins are
+// selected.
+type BinRule int
+
+// Histogram binning rules.
+const (
+	// BinFixed divides the sample range into Count equal width bins.
+	BinFixed BinRule = iota
+	// BinSturges selects ceil(log2(n))+1 equal width bins.
+	BinSturges
+	// BinFreedmanDiaconis selects the bin width 2×IQR/∛n. If the
+	// interquartile range is zero, the rule falls back to
+	// BinSturges.
+	BinFreedmanDiaconis
+	// BinLog divides the sample range into Count bins of equal
+	// width on the logarithmic scale. All samples must be positive.
+	BinLog
+)
+
+var binRules = map[BinRule]string{
+	BinFixed:            "fixed",
+	BinSturges:          "sturges",
+	BinFreedmanDiaconis: "freedman-diaconis",
+	BinLog:              "log",
+}
+
+func (rule BinRule) String() string {
+	name, ok := binRules[rule]
+	if ok {
+		return name
+	}
+	return fmt.Sprintf("{BinRule %d}", rule)
+}
+
+// Binning defines histogram binning options.
+type Binning struct {
+	Rule BinRule
+	// Count specifies the number of bins for the BinFixed and BinLog
+	// rules. The zero value selects the count with the BinSturges
+	// rule.
+	Count int
+}
+
+// Histogram implements a histogram of samples. The bin i covers the
+// values [Edges[i]...Edges[i+1]). The last bin includes also its
+// upper edge.
+type Histogram struct {
+	Edges  []float64
+	Counts []int
+}
+
+// NewHistogram creates a histogram of the finite samples.
+func NewHistogram(samples []float64, binning Binning) (*Histogram, error) {
+	var sorted []float64
+	for _, v := range samples {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		sorted = append(sorted, v)
+	}
+	if len(sorted) == 0 {
+		return &Histogram{}, nil
+	}
+	sort.Float64s(sorted)
+
+	n := len(sorted)
+	min := sorted[0]
+	max := sorted[n-1]
+
+	sturges := int(math.Ceil(math.Log2(float64(n)))) + 1
+
+	var edges []float64
+	switch binning.Rule {
+	case BinFixed, BinSturges, BinFreedmanDiaconis:
+		count := sturges
+		if binning.Rule == BinFixed && binning.Count > 0 {
+			count = binning.Count
+		} else if binning.Rule == BinFreedmanDiaconis {
+			iqr := percentile(sorted, 0.75) - percentile(sorted, 0.25)
+			if iqr > 0 {
+				width := 2 * iqr / math.Cbrt(float64(n))
+				count = int(math.Ceil((max - min) / width))
+				if count > n {
+					count = n
+				}
+			}
+		}
+		edges = linearEdges(min, max, count)
+
+	case BinLog:
+		if min <= 0 {
+			return nil, errors.New("log bins require positive samples")
+		}
+		count := sturges
+		if binning.Count > 0 {
+			count = binning.Count
+		}
+		edges = logEdges(min, max, count)
+
+	default:
+		return nil, fmt.Errorf("unknown bin rule: %v", binning.Rule)
+	}
+
+	h := &Histogram{
+		Edges:  edges,
+		Counts: make([]int, len(edges)-1),
+	}
+	for _, v := range sorted {
+		h.Counts[h.Bin(v)]++
+	}
+	return h, nil
+}
+
+func linearEdges(min, max float64, count int) []float64 {
+	if count < 1 || min == max {
+		count = 1
+	}
+	edges := make([]float64, count+1)
+	for i := range edges {
+		edges[i] = min + (max-min)*float64(i)/float64(count)
+	}
+	edges[count] = max
+	return edges
+}
+
+func logEdges(min, max float64, count int) []float64 {
+	if count < 1 || min == max {
+		count = 1
+	}
+	lmin := math.Log(min)
+	lmax := math.Log(max)
+
+	edges := make([]float64, count+1)
+	for i := range edges {
+		edges[i] = math.Exp(lmin + (lmax-lmin)*float64(i)/float64(count))
+	}
+	edges[0] = min
+	edges[count] = max
+	return edges
+}
+
+// Bin returns the index of the bin containing the value v. The
+// values outside the histogram range are clamped to the first and
+// last bin. The function returns -1 for an empty histogram.
+func (h *Histogram) Bin(v float64) int {
+	bins := len(h.Counts)
+	if bins == 0 {
+		return -1
+	}
+	idx := sort.Search(len(h.Edges), func(i int) bool {
+		return h.Edges[i] > v
+	}) - 1
+	if idx < 0 {
+		return 0
+	}
+	if idx >= bins {
+		return bins - 1
+	}
+	return idx
+}
+
+// String creates a histogram chart of the bin counts.
+func (h *Histogram) String() string {
+	return Range(0, h.max(), h.Counts)
+}
+
+func (h *Histogram) max() int {
+	var max int
+	for _, c := range h.Counts {
+		if c > max {
+			max = c
+		}
+	}
+	return max
+}
@@ -0,0 +1,114 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package sparkline
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHistogramFixed(t *testing.T) {
+	h, err := NewHistogram([]float64{0, 1, 1, 2, 3, 3, 3, 4, math.NaN()},
+		Binning{
+			Rule:  BinFixed,
+			Count: 4,
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	edges := []float64{0, 1, 2, 3, 4}
+	counts := []int{1, 2, 1, 4}
+	for i, e := range edges {
+		if h.Edges[i] != e {
+			t.Errorf("Edges: got %v, expected %v", h.Edges, edges)
+			break
+		}
+	}
+	for i, c := range counts {
+		if h.Counts[i] != c {
+			t.Errorf("Counts: got %v, expected %v", h.Counts, counts)
+			break
+		}
+	}
+	if s, e := h.String(), "▂▄▂█"; s != e {
+		t.Errorf("String: got %q, expected %q", s, e)
+	}
+}
+
+func TestHistogramRules(t *testing.T) {
+	var samples []float64
+	for i := 1; i <= 100; i++ {
+		samples = append(samples, float64(i))
+	}
+
+	h, err := NewHistogram(samples, Binning{Rule: BinSturges})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h.Counts) != 8 {
+		t.Errorf("Sturges: got %v bins, expected 8", len(h.Counts))
+	}
+
+	h, err = NewHistogram(samples, Binning{Rule: BinFreedmanDiaconis})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h.Counts) != 5 {
+		t.Errorf("Freedman-Diaconis: got %v bins, expected 5", len(h.Counts))
+	}
+
+	var total int
+	for _, c := range h.Counts {
+		total += c
+	}
+	if total != len(samples) {
+		t.Errorf("got %v samples, expected %v", total, len(samples))
+	}
+}
+
+func TestHistogramLog(t *testing.T) {
+	h, err := NewHistogram([]float64{1, 5, 10, 50, 100, 500, 1000},
+		Binning{
+			Rule:  BinLog,
+			Count: 3,
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := []int{2, 2, 3}
+	for i, c := range counts {
+		if h.Counts[i] != c {
+			t.Errorf("Counts: got %v, expected %v", h.Counts, counts)
+			break
+		}
+	}
+	if math.Abs(h.Edges[1]-10) > 1e-9 || math.Abs(h.Edges[2]-100) > 1e-9 {
+		t.Errorf("Edges: got %v", h.Edges)
+	}
+
+	_, err = NewHistogram([]float64{0, 1}, Binning{Rule: BinLog})
+	if err == nil {
+		t.Errorf("log bins accepted non-positive samples")
+	}
+}
+
+func TestHistogramEmpty(t *testing.T) {
+	h, err := NewHistogram(nil, Binning{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.String() != "" || h.Bin(1) != -1 {
+		t.Errorf("empty histogram: %v", h)
+	}
+	h, err = NewHistogram([]float64{3, 3}, Binning{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h.Counts) != 1 || h.Counts[0] != 2 {
+		t.Errorf("constant samples: got %v", h.Counts)
+	}
+}
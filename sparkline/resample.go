@@ -0,0 +1,226 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package sparkline
+
+import (
+	"fmt"
+	"math"
+)
+
+// Aggregation defines how values are aggregated when a series is
+// downsampled.
+type Aggregation int
+
+// Aggregation functions. The AggMin, AggMax, AggMean, and AggLast
+// aggregate the values of each bucket. The AggLTTB selects one value
+// from each bucket with the Largest-Triangle-Three-Buckets algorithm.
+const (
+	AggMean Aggregation = iota
+	AggMin
+	AggMax
+	AggLast
+	AggLTTB
+)
+
+var aggregations = map[Aggregation]string{
+	AggMean: "mean",
+	AggMin:  "min",
+	AggMax:  "max",
+	AggLast: "last",
+	AggLTTB: "lttb",
+}
+
+func (agg Aggregation) String() string {
+	name, ok := aggregations[agg]
+	if ok {
+		return name
+	}
+	return fmt.Sprintf("{Aggregation %d}", agg)
+}
+
+// Resampler resamples series to a fixed width.
+type Resampler struct {
+	// Width specifies the number of values in the resampled series.
+	Width int
+	// Aggregation specifies how long series are downsampled.
+	Aggregation Aggregation
+	// Interpolate specifies if series shorter than width are
+	// upsampled with linear interpolation. If interpolation is not
+	// enabled, short series are not resampled.
+	Interpolate bool
+}
+
+// New creates a histogram chart of the resampled values. The chart is
+// scaled to the [min...max] of the finite values in the resampled
+// series.
+func (r *Resampler) New(values []float64) string {
+	return NewFloat(r.Resample(values))
+}
+
+// Range creates a histogram chart of the resampled values. The chart
+// is scaled to [min...max].
+func (r *Resampler) Range(min, max float64, values []float64) string {
+	return RangeFloat(min, max, r.Resample(values))
+}
+
+// Resample resamples values to Width values. The NaN values are
+// ignored in aggregation, and a bucket without any other values
+// aggregates to NaN. The function always returns a new slice, also
+// when values are not resampled.
+func (r *Resampler) Resample(values []float64) []float64 {
+	n := len(values)
+	if r.Width <= 0 || n == r.Width || (n < r.Width && !r.Interpolate) {
+		return append([]float64(nil), values...)
+	}
+	if n < r.Width {
+		return interpolate(values, r.Width)
+	}
+	if r.Aggregation == AggLTTB {
+		return lttb(values, r.Width)
+	}
+
+	result := make([]float64, r.Width)
+	for i := range result {
+		start := i * n / r.Width
+		end := (i + 1) * n / r.Width
+		result[i] = aggregate(r.Aggregation, values[start:end])
+	}
+	return result
+}
+
+func aggregate(agg Aggregation, values []float64) float64 {
+	result := math.NaN()
+	var count int
+
+	for _, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		count++
+		if count == 1 {
+			result = v
+			continue
+		}
+		switch agg {
+		case AggMin:
+			if v < result {
+				result = v
+			}
+		case AggMax:
+			if v > result {
+				result = v
+			}
+		case AggLast:
+			result = v
+		default:
+			result += v
+		}
+	}
+	switch agg {
+	case AggMin, AggMax, AggLast:
+	default:
+		if count > 0 {
+			result /= float64(count)
+		}
+	}
+	return result
+}
+
+// interpolate upsamples values to width values with linear
+// interpolation.
+func interpolate(values []float64, width int) []float64 {
+	n := len(values)
+	result := make([]float64, width)
+	for i := range result {
+		if n == 1 || width == 1 {
+			result[i] = values[0]
+			continue
+		}
+		x := float64(i) * float64(n-1) / float64(width-1)
+		idx := int(x)
+		if idx >= n-1 {
+			result[i] = values[n-1]
+			continue
+		}
+		fract := x - float64(idx)
+		result[i] = values[idx] + (values[idx+1]-values[idx])*fract
+	}
+	return result
+}
+
+// lttb downsamples values to width values with the
+// Largest-Triangle-Three-Buckets algorithm. The first and last
+// values are always selected and the rest of the values are divided
+// into width-2 buckets. From each bucket, the algorithm selects the
+// value that forms the largest triangle with the previously selected
+// value and the average of the next bucket. If width is 1, only the
+// first value is selected.
+func lttb(values []float64, width int) []float64 {
+	n := len(values)
+	result := make([]float64, 0, width)
+	result = append(result, values[0])
+	if width == 1 {
+		return result
+	}
+
+	every := float64(n-2) / float64(width-2)
+	a := 0
+
+	for i := 0; i < width-2; i++ {
+		avgStart := int(float64(i+1)*every) + 1
+		avgEnd := int(float64(i+2)*every) + 1
+		if avgEnd > n {
+			avgEnd = n
+		}
+		var avgX, avgY float64
+		var count int
+		for j := avgStart; j < avgEnd; j++ {
+			if math.IsNaN(values[j]) {
+				continue
+			}
+			avgX += float64(j)
+			avgY += values[j]
+			count++
+		}
+		if count > 0 {
+			avgX /= float64(count)
+			avgY /= float64(count)
+		} else {
+			avgX = float64(n - 1)
+			avgY = values[n-1]
+		}
+
+		ax := float64(a)
+		ay := values[a]
+		if math.IsNaN(ay) {
+			ay = avgY
+		}
+
+		start := int(float64(i)*every) + 1
+		end := int(float64(i+1)*every) + 1
+		maxArea := -1.0
+		next := -1
+		for j := start; j < end; j++ {
+			v := values[j]
+			if math.IsNaN(v) {
+				continue
+			}
+			area := math.Abs((ax-avgX)*(v-ay) - (ax-float64(j))*(avgY-ay))
+			if area > maxArea {
+				maxArea = area
+				next = j
+			}
+		}
+		if next < 0 {
+			result = append(result, math.NaN())
+			continue
+		}
+		result = append(result, values[next])
+		a = next
+	}
+	return append(result, values[n-1])
+}
@@ -0,0 +1,149 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package sparkline
+
+import (
+	"math"
+	"testing"
+)
+
+var resampleTests = []struct {
+	r      Resampler
+	values []float64
+	result []float64
+}{
+	{
+		r: Resampler{
+			Width:       2,
+			Aggregation: AggMean,
+		},
+		values: []float64{1, 2, 3, 5, 7, 9},
+		result: []float64{2, 7},
+	},
+	{
+		r: Resampler{
+			Width:       3,
+			Aggregation: AggMin,
+		},
+		values: []float64{4, 2, 3, 5, 7, math.NaN()},
+		result: []float64{2, 3, 7},
+	},
+	{
+		r: Resampler{
+			Width:       3,
+			Aggregation: AggMax,
+		},
+		values: []float64{4, 2, 3, 5, 7, 1},
+		result: []float64{4, 5, 7},
+	},
+	{
+		r: Resampler{
+			Width:       2,
+			Aggregation: AggLast,
+		},
+		values: []float64{4, 2, 3, 5, 7, math.NaN()},
+		result: []float64{3, 7},
+	},
+	{
+		r: Resampler{
+			Width:       3,
+			Aggregation: AggLTTB,
+		},
+		values: []float64{0, 1, 9, 1, 0},
+		result: []float64{0, 9, 0},
+	},
+	{
+		r: Resampler{
+			Width:       2,
+			Aggregation: AggLTTB,
+		},
+		values: []float64{1, 9, 9, 2},
+		result: []float64{1, 2},
+	},
+	{
+		r: Resampler{
+			Width:       1,
+			Aggregation: AggLTTB,
+		},
+		values: []float64{1, 9, 9, 2},
+		result: []float64{1},
+	},
+	{
+		r: Resampler{
+			Width: 5,
+		},
+		values: []float64{0, 4},
+		result: []float64{0, 4},
+	},
+	{
+		r: Resampler{
+			Width:       5,
+			Interpolate: true,
+		},
+		values: []float64{0, 4},
+		result: []float64{0, 1, 2, 3, 4},
+	},
+}
+
+func TestResample(t *testing.T) {
+	for idx, test := range resampleTests {
+		result := test.r.Resample(test.values)
+		if len(result) != len(test.result) {
+			t.Errorf("%d: got %v, expected %v", idx, result, test.result)
+			continue
+		}
+		for i, v := range result {
+			if v != test.result[i] {
+				t.Errorf("%d: got %v, expected %v", idx, result, test.result)
+				break
+			}
+		}
+	}
+}
+
+func TestResampleCopy(t *testing.T) {
+	values := []float64{1, 2, 3}
+	for _, width := range []int{0, 3, 5} {
+		r := &Resampler{
+			Width: width,
+		}
+		result := r.Resample(values)
+		result[0] = 0
+		if values[0] != 1 {
+			t.Fatalf("width %d: result aliases values", width)
+		}
+	}
+}
+
+func TestResampleLTTB(t *testing.T) {
+	var values []float64
+	for i := 0; i < 1000; i++ {
+		values = append(values, math.Sin(float64(i)/50))
+	}
+	values[500] = 10
+
+	r := &Resampler{
+		Width:       40,
+		Aggregation: AggLTTB,
+	}
+	result := r.Resample(values)
+	if len(result) != 40 {
+		t.Fatalf("got %v values, expected 40", len(result))
+	}
+	var found bool
+	for _, v := range result {
+		if v == 10 {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("LTTB dropped the spike")
+	}
+	if line := r.New(values); len([]rune(line)) != 40 {
+		t.Errorf("New: got %v cells, expected 40", len([]rune(line)))
+	}
+}
@@ -0,0 +1,188 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package sparkline
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"unicode/utf8"
+)
+
+// RangeMode defines how the ring buffer charts are scaled.
+type RangeMode int
+
+// Range modes.
+const (
+	// AutoRange scales the chart to the [min...max] of the values in
+	// the buffer.
+	AutoRange RangeMode = iota
+	// StickyRange scales the chart to the [min...max] of all values
+	// added to the buffer since it was created or reset. The range
+	// never shrinks.
+	StickyRange
+)
+
+var rangeModes = map[RangeMode]string{
+	AutoRange:   "auto",
+	StickyRange: "sticky",
+}
+
+func (m RangeMode) String() string {
+	name, ok := rangeModes[m]
+	if ok {
+		return name
+	}
+	return fmt.Sprintf("{RangeMode %d}", m)
+}
+
+// Ring implements a fixed size ring buffer of values for live
+// charts. The buffer keeps the last N values. It is safe to add
+// values and render charts from multiple goroutines.
+type Ring struct {
+	m      sync.Mutex
+	mode   RangeMode
+	values []float64
+	next   int
+	count  int
+	sticky bool
+	min    float64
+	max    float64
+	buf    []byte
+}
+
+// NewRing creates a new ring buffer for the last n values. The mode
+// specifies how the charts are scaled.
+func NewRing(n int, mode RangeMode) *Ring {
+	if n < 1 {
+		n = 1
+	}
+	return &Ring{
+		mode:   mode,
+		values: make([]float64, n),
+	}
+}
+
+// Add adds the value v to the buffer. If the buffer is full, the
+// oldest value is dropped.
+func (r *Ring) Add(v float64) {
+	r.m.Lock()
+	r.values[r.next] = v
+	r.next = (r.next + 1) % len(r.values)
+	if r.count < len(r.values) {
+		r.count++
+	}
+	if !math.IsNaN(v) && !math.IsInf(v, 0) {
+		if !r.sticky {
+			r.min = v
+			r.max = v
+			r.sticky = true
+		} else if v < r.min {
+			r.min = v
+		} else if v > r.max {
+			r.max = v
+		}
+	}
+	r.m.Unlock()
+}
+
+// Reset removes all values from the buffer and resets the sticky
+// range.
+func (r *Ring) Reset() {
+	r.m.Lock()
+	r.next = 0
+	r.count = 0
+	r.sticky = false
+	r.min = 0
+	r.max = 0
+	r.m.Unlock()
+}
+
+// Len returns the number of values in the buffer.
+func (r *Ring) Len() int {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.count
+}
+
+// Values returns a copy of the buffer values from the oldest to the
+// newest.
+func (r *Ring) Values() []float64 {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	result := make([]float64, 0, r.count)
+	r.each(func(v float64) {
+		result = append(result, v)
+	})
+	return result
+}
+
+// each calls f for the buffer values from the oldest to the
+// newest. The caller must hold the buffer lock.
+func (r *Ring) each(f func(v float64)) {
+	start := r.next - r.count
+	if start < 0 {
+		start += len(r.values)
+	}
+	for i := 0; i < r.count; i++ {
+		f(r.values[(start+i)%len(r.values)])
+	}
+}
+
+// String creates a histogram chart of the buffer values. The chart
+// is scaled according to the buffer's range mode.
+func (r *Ring) String() string {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	var min, max float64
+	if r.mode == StickyRange {
+		min, max = r.min, r.max
+	} else {
+		var found bool
+		r.each(func(v float64) {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return
+			}
+			if !found {
+				min, max = v, v
+				found = true
+			} else if v < min {
+				min = v
+			} else if v > max {
+				max = v
+			}
+		})
+	}
+	return r.render(min, max)
+}
+
+// Range creates a histogram chart of the buffer values. The chart is
+// scaled to [min...max].
+func (r *Ring) Range(min, max float64) string {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	if max < min {
+		min = max
+	}
+	return r.render(min, max)
+}
+
+// render renders the chart into the buffer's reusable render
+// buffer. The caller must hold the buffer lock.
+func (r *Ring) render(min, max float64) string {
+	var tmp [utf8.UTFMax]byte
+
+	r.buf = r.buf[:0]
+	r.each(func(v float64) {
+		n := utf8.EncodeRune(tmp[:], Linear.tick(min, max, v))
+		r.buf = append(r.buf, tmp[:n]...)
+	})
+	return string(r.buf)
+}
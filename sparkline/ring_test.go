@@ -0,0 +1,114 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package sparkline
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestRing(t *testing.T) {
+	r := NewRing(4, AutoRange)
+	if s := r.String(); s != "" {
+		t.Errorf("empty ring: got %q", s)
+	}
+	for _, v := range []float64{100, 0, 1, 2, 7} {
+		r.Add(v)
+	}
+	if r.Len() != 4 {
+		t.Errorf("Len: got %v, expected 4", r.Len())
+	}
+	values := r.Values()
+	expected := []float64{0, 1, 2, 7}
+	for i, v := range values {
+		if v != expected[i] {
+			t.Errorf("Values: got %v, expected %v", values, expected)
+			break
+		}
+	}
+	if s, e := r.String(), "▁▂▃█"; s != e {
+		t.Errorf("AutoRange: got %q, expected %q", s, e)
+	}
+	if s, e := r.Range(0, 3), "▁▃▅░"; s != e {
+		t.Errorf("Range: got %q, expected %q", s, e)
+	}
+
+	r = NewRing(4, StickyRange)
+	for _, v := range []float64{100, 0, 1, 2, 7} {
+		r.Add(v)
+	}
+	if s, e := r.String(), "▁▁▁▁"; s != e {
+		t.Errorf("StickyRange: got %q, expected %q", s, e)
+	}
+	r.Reset()
+	r.Add(1)
+	r.Add(2)
+	if s, e := r.String(), "▁█"; s != e {
+		t.Errorf("Reset: got %q, expected %q", s, e)
+	}
+}
+
+func TestRingConcurrent(t *testing.T) {
+	r := NewRing(100, AutoRange)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				r.Add(float64(g*1000 + i))
+				if i%100 == 0 {
+					_ = r.String()
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if r.Len() != 100 {
+		t.Errorf("Len: got %v, expected 100", r.Len())
+	}
+}
+
+func BenchmarkRingAdd(b *testing.B) {
+	r := NewRing(1000, AutoRange)
+	b.RunParallel(func(pb *testing.PB) {
+		var v float64
+		for pb.Next() {
+			r.Add(v)
+			v++
+		}
+	})
+}
+
+func BenchmarkRingString(b *testing.B) {
+	r := NewRing(80, AutoRange)
+	for i := 0; i < 80; i++ {
+		r.Add(float64(i % 13))
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = r.String()
+	}
+}
+
+// BenchmarkRingLive simulates one second of a live dashboard where
+// values are added at 1 kHz and the chart is rendered at 30 fps.
+func BenchmarkRingLive(b *testing.B) {
+	r := NewRing(80, StickyRange)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for frame := 0; frame < 30; frame++ {
+			for j := 0; j < 1000/30; j++ {
+				r.Add(float64(j % 17))
+			}
+			_ = r.String()
+		}
+	}
+}
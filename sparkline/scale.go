@@ -0,0 +1,172 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package sparkline
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Scale defines how values are mapped to chart levels.
+type Scale int
+
+// Chart scales. The logarithmic scales Log10 and Log2 are defined for
+// positive values. If the range minimum is not positive, it is
+// replaced with the smallest positive value of the chart and the
+// non-positive values are rendered as values smaller than the
+// range. If the chart does not have positive values, the logarithmic
+// scales fall back to the Linear scale. The SymLog scale is a symmetric logarithmic scale
+// sign(v)×log10(1+|v|) that is linear around zero and that handles
+// both positive and negative values.
+const (
+	Linear Scale = iota
+	Log10
+	Log2
+	SymLog
+)
+
+var scales = map[Scale]string{
+	Linear: "linear",
+	Log10:  "log10",
+	Log2:   "log2",
+	SymLog: "symlog",
+}
+
+func (s Scale) String() string {
+	name, ok := scales[s]
+	if ok {
+		return name
+	}
+	return fmt.Sprintf("{Scale %d}", s)
+}
+
+// New creates a histogram chart of values with the scale s. The chart
+// is scaled to [min...max] values in the values array.
+func (s Scale) New(values []int) string {
+	if len(values) == 0 {
+		return ""
+	}
+	min := values[0]
+	max := values[0]
+	for _, v := range values {
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+	return s.Range(min, max, values)
+}
+
+// Range creates a histogram chart of values with the scale s. The
+// chart is scaled to [min...max]. The values outside the range are
+// rendered as with the Range function.
+func (s Scale) Range(min, max int, values []int) string {
+	if s == Linear {
+		return Range(min, max, values)
+	}
+	fvalues := make([]float64, len(values))
+	for i, v := range values {
+		fvalues[i] = float64(v)
+	}
+	return s.RangeFloat(float64(min), float64(max), fvalues)
+}
+
+// NewFloat creates a histogram chart of float64 values with the scale
+// s. The chart is scaled to the [min...max] of the finite values in
+// the values array.
+func (s Scale) NewFloat(values []float64) string {
+	min, max := Bounds(values)
+	return s.RangeFloat(min, max, values)
+}
+
+// RangeFloat creates a histogram chart of float64 values with the
+// scale s. The chart is scaled to [min...max]. The NaN, infinite, and
+// out of range values are rendered as with the RangeFloat function.
+func (s Scale) RangeFloat(min, max float64, values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+	if max < min {
+		min = max
+	}
+	scale, min := s.lower(min, values)
+
+	var sb strings.Builder
+	for _, v := range values {
+		sb.WriteRune(scale.tick(min, max, v))
+	}
+	return sb.String()
+}
+
+// tick returns the histogram chart rune for the value v in the range
+// [min...max].
+func (s Scale) tick(min, max, v float64) rune {
+	pos, c := s.classify(min, max, v)
+	switch c {
+	case missing:
+		return Gap
+	case below:
+		return Below
+	case above:
+		return Above
+	}
+	if min == max {
+		return 0x2581 + 4
+	}
+	return rune(0x2581 + level(pos, 7))
+}
+
+// lower returns the effective scale and range minimum for the
+// scale. For the logarithmic scales, a non-positive min is replaced
+// with the smallest positive value. If values do not have positive
+// values, lower returns the Linear scale and min.
+func (s Scale) lower(min float64, values []float64) (Scale, float64) {
+	if (s != Log10 && s != Log2) || min > 0 {
+		return s, min
+	}
+	result := math.Inf(1)
+	for _, v := range values {
+		if v > 0 && v < result {
+			result = v
+		}
+	}
+	if math.IsInf(result, 1) {
+		return Linear, min
+	}
+	return s, result
+}
+
+// classify classifies the value v against the range [min...max]. For
+// values in range, classify returns also the value's relative
+// position [0...1] on the scale.
+func (s Scale) classify(min, max, v float64) (float64, class) {
+	pos, c := classify(min, max, v)
+	if c != inRange || s == Linear || min == max {
+		return pos, c
+	}
+	tmin := s.transform(min)
+	return (s.transform(v) - tmin) / (s.transform(max) - tmin), inRange
+}
+
+func (s Scale) transform(v float64) float64 {
+	switch s {
+	case Log10:
+		return math.Log10(v)
+	case Log2:
+		return math.Log2(v)
+	case SymLog:
+		if v < 0 {
+			return -math.Log10(1 - v)
+		}
+		return math.Log10(1 + v)
+	default:
+		return v
+	}
+}
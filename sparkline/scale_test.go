@@ -0,0 +1,113 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package sparkline
+
+import (
+	"testing"
+)
+
+var scaleTests = []struct {
+	scale  Scale
+	min    int
+	max    int
+	values []int
+	result string
+}{
+	{
+		scale:  Linear,
+		min:    1,
+		max:    10000,
+		values: []int{1, 10, 100, 1000, 10000},
+		result: "▁▁▁▁█",
+	},
+	{
+		scale:  Log10,
+		min:    1,
+		max:    10000,
+		values: []int{1, 10, 100, 1000, 10000},
+		result: "▁▂▄▆█",
+	},
+	{
+		scale:  Log2,
+		min:    1,
+		max:    128,
+		values: []int{1, 2, 4, 8, 16, 32, 64, 128},
+		result: "▁▂▃▄▅▆▇█",
+	},
+	{
+		scale:  Log10,
+		min:    10,
+		max:    1000,
+		values: []int{5, 10, 100, 1000, 2000},
+		result: "_▁▄█░",
+	},
+	{
+		scale:  Log10,
+		min:    0,
+		max:    100,
+		values: []int{0, 1, 10, 100},
+		result: "_▁▄█",
+	},
+	{
+		scale:  Log10,
+		min:    -8,
+		max:    0,
+		values: []int{-8, -4, 0},
+		result: "▁▄█",
+	},
+	{
+		scale:  SymLog,
+		min:    -999,
+		max:    999,
+		values: []int{-999, -9, 0, 9, 999},
+		result: "▁▃▄▅█",
+	},
+}
+
+func TestScale(t *testing.T) {
+	for idx, test := range scaleTests {
+		result := test.scale.Range(test.min, test.max, test.values)
+		if result != test.result {
+			t.Errorf("%d %v.Range: got %q, expected %q",
+				idx, test.scale, result, test.result)
+		}
+	}
+	if s, e := Log10.New([]int{1, 100}), "▁█"; s != e {
+		t.Errorf("Log10.New: got %q, expected %q", s, e)
+	}
+}
+
+func TestChartScale(t *testing.T) {
+	c := &Chart{
+		Rows:  2,
+		Scale: Log10,
+	}
+	lines := c.Range(1, 10000, []float64{1, 100, 10000})
+	expected := []string{
+		"  █",
+		"▁██",
+	}
+	for idx, line := range lines {
+		if line != expected[idx] {
+			t.Errorf("row %d: got %q, expected %q", idx, line, expected[idx])
+		}
+	}
+}
+
+func TestChartScaleNonPositive(t *testing.T) {
+	c := &Chart{
+		Rows:   2,
+		Scale:  Log2,
+		Labels: true,
+	}
+	lines := c.New([]float64{-4, -2, 0})
+	expected := []string{
+		" 0   █",
+		"-4 ▁██",
+	}
+	checkLines(t, lines, expected)
+}
@@ -1,5 +1,5 @@
 //
-// Copyright (c) 2021-2024 Markku Rossi
+// Copyright (c) 2021-2026 Markku Rossi
 //
 // All rights reserved.
 //
@@ -8,14 +8,31 @@ package sparkline
 
 import (
 	"math"
+	"math/bits"
 	"strings"
 )
 
+// Tick runes for values that are not rendered with the block
+// elements.
+const (
+	// Below is rendered for values smaller than the chart range. It
+	// differs from Gap so that the values below the range can be told
+	// apart from the missing values.
+	Below rune = '_'
+	// Above is rendered for values larger than the chart range.
+	Above rune = '\u2591'
+	// Gap is rendered for missing (NaN) values.
+	Gap rune = ' '
+)
+
 // New creates a histogram chart of values. The chart is scaled to
 // [min...max] values in the values array.
 func New(values []int) string {
-	min := math.MaxInt32
-	max := math.MinInt32
+	if len(values) == 0 {
+		return ""
+	}
+	min := values[0]
+	max := values[0]
 	for _, v := range values {
 		if v < min {
 			min = v
@@ -39,21 +56,123 @@ func Range(min, max int, values []int) string {
 		min = max
 	}
 
-	delta := max - min
+	// The unsigned difference is exact for all int ranges.
+	delta := uint64(max) - uint64(min)
 
 	var sb strings.Builder
 	for _, v := range values {
 		var tick rune
 		if v < min {
-			tick = ' '
+			tick = Below
 		} else if v > max {
-			tick = 0x2591
+			tick = Above
 		} else if delta == 0 {
 			tick = 0x2581 + 4
 		} else {
-			tick = rune(0x2581 + (v-min)*7/delta)
+			tick = rune(0x2581 + mulDiv(uint64(v)-uint64(min), 7, delta))
 		}
 		sb.WriteRune(tick)
 	}
 	return sb.String()
 }
+
+// mulDiv computes a*b/c with a 128-bit intermediate product. The
+// quotient must fit into 64 bits.
+func mulDiv(a, b, c uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	q, _ := bits.Div64(hi, lo, c)
+	return q
+}
+
+// Bounds returns the minimum and maximum of the finite values. The
+// NaN and infinite values are ignored. If values does not have any
+// finite values, Bounds returns 0, 0.
+func Bounds(values []float64) (min, max float64) {
+	var found bool
+	for _, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		if !found {
+			min = v
+			max = v
+			found = true
+			continue
+		}
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+	return
+}
+
+// NewFloat creates a histogram chart of float64 values. The chart is
+// scaled to the [min...max] of the finite values in the values
+// array. See RangeFloat for the rendering of NaN and infinite values.
+func NewFloat(values []float64) string {
+	min, max := Bounds(values)
+	return RangeFloat(min, max, values)
+}
+
+// RangeFloat creates a histogram chart of float64 values. The chart
+// is scaled to [min...max]. Values smaller than min, including -Inf,
+// are rendered with Below, and values larger than max, including
+// +Inf, are rendered with Above. NaN values are rendered with Gap.
+func RangeFloat(min, max float64, values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+	if max < min {
+		min = max
+	}
+
+	var sb strings.Builder
+	for _, v := range values {
+		sb.WriteRune(Linear.tick(min, max, v))
+	}
+	return sb.String()
+}
+
+type class int
+
+const (
+	inRange class = iota
+	below
+	above
+	missing
+)
+
+// classify classifies the value v against the range [min...max]. For
+// values in range, classify returns also the value's relative
+// position [0...1] in the range.
+func classify(min, max, v float64) (float64, class) {
+	switch {
+	case math.IsNaN(v):
+		return 0, missing
+	case v < min:
+		return 0, below
+	case v > max:
+		return 0, above
+	case min == max:
+		return 0.5, inRange
+	}
+	// Halve the operands so that the differences of large
+	// magnitude values do not overflow to infinity.
+	return (v/2 - min/2) / (max/2 - min/2), inRange
+}
+
+// level quantizes the relative position pos [0...1] into levels
+// [0...n].
+func level(pos float64, n int) int {
+	l := int(pos * float64(n))
+	if l < 0 {
+		return 0
+	}
+	if l > n {
+		return n
+	}
+	return l
+}
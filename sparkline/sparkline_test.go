@@ -1,5 +1,5 @@
 //
-// Copyright (c) 2021-2024 Markku Rossi
+// Copyright (c) 2021-2026 Markku Rossi
 //
 // All rights reserved.
 //
@@ -8,6 +8,7 @@ package sparkline
 
 import (
 	"fmt"
+	"math"
 	"testing"
 )
 
@@ -22,3 +23,63 @@ func TestSparkline(t *testing.T) {
 	fmt.Printf("%s\n", Range(25, 65, values))
 	fmt.Printf("%s\n", Range(65, 25, values))
 }
+
+func TestSparklineOverflow(t *testing.T) {
+	values := []int{math.MinInt64, 0, math.MaxInt64}
+	result := New(values)
+	expected := "▁▄█"
+	if result != expected {
+		t.Errorf("New: got %q, expected %q", result, expected)
+	}
+
+	values = []int{math.MaxInt64 - 7, math.MaxInt64}
+	result = New(values)
+	expected = "▁█"
+	if result != expected {
+		t.Errorf("New: got %q, expected %q", result, expected)
+	}
+}
+
+var floatTests = []struct {
+	values []float64
+	result string
+}{
+	{
+		values: nil,
+		result: "",
+	},
+	{
+		values: []float64{0, 0.5, 1},
+		result: "▁▄█",
+	},
+	{
+		values: []float64{0, math.NaN(), 1, math.Inf(1), math.Inf(-1)},
+		result: "▁ █░_",
+	},
+	{
+		values: []float64{-math.MaxFloat64, math.MaxFloat64},
+		result: "▁█",
+	},
+	{
+		values: []float64{3, 3},
+		result: "▅▅",
+	},
+}
+
+func TestSparklineFloat(t *testing.T) {
+	for idx, test := range floatTests {
+		result := NewFloat(test.values)
+		if result != test.result {
+			t.Errorf("%d NewFloat: got %q, expected %q",
+				idx, result, test.result)
+		}
+	}
+	result := RangeFloat(0, 10, []float64{-1, 5, 11})
+	if result != "_▄░" {
+		t.Errorf("RangeFloat: got %q, expected %q", result, "_▄░")
+	}
+	result = RangeFloat(0, 10, []float64{math.NaN(), -1})
+	if result != string([]rune{Gap, Below}) || Gap == Below {
+		t.Errorf("RangeFloat: got %q, Gap and Below must differ", result)
+	}
+}
@@ -0,0 +1,190 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package sparkline
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/markkurossi/text"
+)
+
+// Summary summarizes the finite values of a series. If the series
+// does not have any finite values, the statistics are NaN and the
+// indices are -1.
+type Summary struct {
+	// Count specifies the number of finite values.
+	Count int
+	Min   float64
+	Max   float64
+	Mean  float64
+	P50   float64
+	P95   float64
+	// Last specifies the last finite value.
+	Last float64
+	// Trend specifies the slope of the least-squares line fitted to
+	// the values, in value units per sample.
+	Trend float64
+	// MinIndex, MaxIndex, and LastIndex specify the indices of the
+	// first minimum, first maximum, and last finite value.
+	MinIndex  int
+	MaxIndex  int
+	LastIndex int
+}
+
+// Summarize computes the summary of values.
+func Summarize(values []float64) Summary {
+	nan := math.NaN()
+	s := Summary{
+		Min:       nan,
+		Max:       nan,
+		Mean:      nan,
+		P50:       nan,
+		P95:       nan,
+		Last:      nan,
+		Trend:     nan,
+		MinIndex:  -1,
+		MaxIndex:  -1,
+		LastIndex: -1,
+	}
+
+	var sorted []float64
+	var sumX, sumY, sumXY, sumXX float64
+
+	for i, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		if s.Count == 0 || v < s.Min {
+			s.Min = v
+			s.MinIndex = i
+		}
+		if s.Count == 0 || v > s.Max {
+			s.Max = v
+			s.MaxIndex = i
+		}
+		s.Count++
+		s.Last = v
+		s.LastIndex = i
+		sorted = append(sorted, v)
+
+		x := float64(i)
+		sumX += x
+		sumY += v
+		sumXY += x * v
+		sumXX += x * x
+	}
+	if s.Count == 0 {
+		return s
+	}
+	n := float64(s.Count)
+	s.Mean = sumY / n
+
+	if d := n*sumXX - sumX*sumX; d != 0 {
+		s.Trend = (n*sumXY - sumX*sumY) / d
+	} else {
+		s.Trend = 0
+	}
+
+	sort.Float64s(sorted)
+	s.P50 = percentile(sorted, 0.50)
+	s.P95 = percentile(sorted, 0.95)
+
+	return s
+}
+
+func (s Summary) String() string {
+	return fmt.Sprintf("count=%v, min=%g, max=%g, mean=%g, p50=%g, p95=%g, "+
+		"last=%g, trend=%g",
+		s.Count, s.Min, s.Max, s.Mean, s.P50, s.P95, s.Last, s.Trend)
+}
+
+// percentile computes the p percentile of the sorted values with
+// linear interpolation between the closest ranks.
+func percentile(sorted []float64, p float64) float64 {
+	x := p * float64(len(sorted)-1)
+	idx := int(x)
+	if idx >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	fract := x - float64(idx)
+	return sorted[idx] + (sorted[idx+1]-sorted[idx])*fract
+}
+
+// Annotated defines a histogram chart with summary annotations of
+// the form "min 3 ▁▂▅█▃ max 9 last 4".
+type Annotated struct {
+	// Format specifies the fmt verb for formatting the annotation
+	// values. The empty value uses "%g".
+	Format string
+	// MinColor, MaxColor, and LastColor specify optional colors for
+	// highlighting the minimum, maximum, and last value cells.
+	MinColor  color.Color
+	MaxColor  color.Color
+	LastColor color.Color
+}
+
+// New creates an annotated histogram chart of values. The chart is
+// scaled to the [min...max] of the finite values in the values
+// array. The function returns also the summary of values.
+func (a *Annotated) New(values []float64) (*text.Text, Summary) {
+	s := Summarize(values)
+	if s.Count == 0 {
+		return a.render(0, 0, values, s), s
+	}
+	return a.render(s.Min, s.Max, values, s), s
+}
+
+// Range creates an annotated histogram chart of values. The chart is
+// scaled to [min...max]. The function returns also the summary of
+// values.
+func (a *Annotated) Range(min, max float64, values []float64) (
+	*text.Text, Summary) {
+
+	if max < min {
+		min = max
+	}
+	s := Summarize(values)
+	return a.render(min, max, values, s), s
+}
+
+func (a *Annotated) render(min, max float64, values []float64,
+	s Summary) *text.Text {
+
+	format := a.Format
+	if len(format) == 0 {
+		format = "%g"
+	}
+
+	result := text.New().Plainf("min "+format+" ", s.Min)
+
+	var span text.Span
+	for i, v := range values {
+		var fg color.Color
+		switch i {
+		case s.LastIndex:
+			fg = a.LastColor
+		case s.MaxIndex:
+			fg = a.MaxColor
+		case s.MinIndex:
+			fg = a.MinColor
+		}
+		if len(span.Content) > 0 && span.FG != fg {
+			result.AppendSpan(span)
+			span = text.Span{}
+		}
+		span.FG = fg
+		span.Content += string(Linear.tick(min, max, v))
+	}
+	if len(span.Content) > 0 {
+		result.AppendSpan(span)
+	}
+
+	return result.Plainf(" max "+format+" last "+format, s.Max, s.Last)
+}
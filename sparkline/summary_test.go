@@ -0,0 +1,82 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package sparkline
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestSummarize(t *testing.T) {
+	s := Summarize([]float64{3, 5, math.NaN(), 9, 4, 1, 7})
+	if s.Count != 6 {
+		t.Errorf("Count: got %v, expected 6", s.Count)
+	}
+	if s.Min != 1 || s.MinIndex != 5 {
+		t.Errorf("Min: got %v@%v, expected 1@5", s.Min, s.MinIndex)
+	}
+	if s.Max != 9 || s.MaxIndex != 3 {
+		t.Errorf("Max: got %v@%v, expected 9@3", s.Max, s.MaxIndex)
+	}
+	if s.Last != 7 || s.LastIndex != 6 {
+		t.Errorf("Last: got %v@%v, expected 7@6", s.Last, s.LastIndex)
+	}
+	if s.Mean != 29.0/6 {
+		t.Errorf("Mean: got %v, expected %v", s.Mean, 29.0/6)
+	}
+	if s.P50 != 4.5 {
+		t.Errorf("P50: got %v, expected 4.5", s.P50)
+	}
+	if s.P95 != 8.5 {
+		t.Errorf("P95: got %v, expected 8.5", s.P95)
+	}
+
+	s = Summarize([]float64{1, 2, 3, 4})
+	if s.Trend != 1 {
+		t.Errorf("Trend: got %v, expected 1", s.Trend)
+	}
+
+	s = Summarize(nil)
+	if s.Count != 0 || !math.IsNaN(s.Min) || s.MinIndex != -1 {
+		t.Errorf("empty: got %v", s)
+	}
+}
+
+func TestAnnotated(t *testing.T) {
+	a := &Annotated{}
+	txt, s := a.New([]float64{3, 4, 6, 9, 5, 4})
+	expected := "min 3 ▁▂▄█▃▂ max 9 last 4"
+	if str := txt.String(); str != expected {
+		t.Errorf("got %q, expected %q", str, expected)
+	}
+	if s.Max != 9 {
+		t.Errorf("summary: got %v", s)
+	}
+
+	red := color.NRGBA{R: 0xff, A: 0xff}
+	blue := color.NRGBA{B: 0xff, A: 0xff}
+	a = &Annotated{
+		Format:    "%.1f",
+		MaxColor:  red,
+		LastColor: blue,
+	}
+	txt, _ = a.Range(0, 10, []float64{3, 10, 5, 4})
+	expected = "min 3.0 ▃█▄▃ max 10.0 last 4.0"
+	if str := txt.String(); str != expected {
+		t.Errorf("got %q, expected %q", str, expected)
+	}
+	var colored []string
+	for _, span := range txt.Spans {
+		if span.FG != nil {
+			colored = append(colored, span.Content)
+		}
+	}
+	if len(colored) != 2 || colored[0] != "█" || colored[1] != "▃" {
+		t.Errorf("highlights: got %q", colored)
+	}
+}
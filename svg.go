@@ -0,0 +1,103 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package text
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+)
+
+// SVGOptions define options for the SVG representation of the text.
+type SVGOptions struct {
+	// X and Y specify the position of the text anchor point.
+	X, Y float64
+	// Align specifies how the text is aligned relative to the anchor
+	// point: AlignLeft starts the text from the anchor point,
+	// AlignRight ends the text at the anchor point, and AlignCenter
+	// centers the text at the anchor point.
+	Align Align
+	// FontFamily specifies an optional font family.
+	FontFamily string
+	// FontSize specifies an optional font size in user units.
+	FontSize float64
+}
+
+// SVG creates SVG <text> element representation of the text. The
+// bold and oblique styles are rendered with <tspan> elements, the
+// foreground color with the fill attribute, and links with <a>
+// elements. The background colors are ignored.
+func (text *Text) SVG(opts SVGOptions) string {
+	var sb strings.Builder
+
+	sb.WriteString(`<text x="`)
+	sb.WriteString(svgNumber(opts.X))
+	sb.WriteString(`" y="`)
+	sb.WriteString(svgNumber(opts.Y))
+	sb.WriteString(`"`)
+
+	switch opts.Align {
+	case AlignRight:
+		sb.WriteString(` text-anchor="end"`)
+	case AlignCenter:
+		sb.WriteString(` text-anchor="middle"`)
+	}
+	if len(opts.FontFamily) > 0 {
+		sb.WriteString(` font-family="`)
+		svgEscape(&sb, opts.FontFamily)
+		sb.WriteString(`"`)
+	}
+	if opts.FontSize > 0 {
+		sb.WriteString(` font-size="`)
+		sb.WriteString(svgNumber(opts.FontSize))
+		sb.WriteString(`"`)
+	}
+	sb.WriteString(` xml:space="preserve">`)
+	text.svgSpans(&sb)
+	sb.WriteString(`</text>`)
+
+	return sb.String()
+}
+
+func (text *Text) svgSpans(sb *strings.Builder) {
+	for _, span := range text.Spans {
+		if span.Link != nil {
+			sb.WriteString(`<a href="`)
+			svgEscape(sb, span.Content)
+			sb.WriteString(`">`)
+			span.Link.svgSpans(sb)
+			sb.WriteString(`</a>`)
+			continue
+		}
+
+		var attrs string
+		if span.Bold {
+			attrs += ` font-weight="bold"`
+		}
+		if span.Oblique {
+			attrs += ` font-style="italic"`
+		}
+		if span.FG != nil {
+			attrs += ` fill="` + HexColor(span.FG) + `"`
+		}
+		if len(attrs) == 0 {
+			svgEscape(sb, span.Content)
+			continue
+		}
+		sb.WriteString(`<tspan` + attrs + `>`)
+		svgEscape(sb, span.Content)
+		sb.WriteString(`</tspan>`)
+	}
+}
+
+func svgEscape(sb *strings.Builder, s string) {
+	xml.EscapeText(sb, []byte(s))
+}
+
+func svgNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
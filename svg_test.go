@@ -0,0 +1,55 @@
+//
+// Copyright (c) 2026 Markku Rossi
+//
+// All rights reserved.
+//
+
+package text
+
+import (
+	"image/color"
+	"testing"
+)
+
+var svgTests = []struct {
+	text *Text
+	opts SVGOptions
+	svg  string
+}{
+	{
+		text: New().Plain(`<"Tom" & 'Jerry'>`),
+		svg:  `<text x="0" y="0" xml:space="preserve">&lt;&#34;Tom&#34; &amp; &#39;Jerry&#39;&gt;</text>`,
+	},
+	{
+		text: New().Bold("bold").Plain(" ").Oblique("oblique").
+			BoldOblique("both"),
+		opts: SVGOptions{
+			X:          10,
+			Y:          20.5,
+			Align:      AlignCenter,
+			FontFamily: "Helvetica & Arial",
+			FontSize:   12,
+		},
+		svg: `<text x="10" y="20.5" text-anchor="middle" font-family="Helvetica &amp; Arial" font-size="12" xml:space="preserve"><tspan font-weight="bold">bold</tspan> <tspan font-style="italic">oblique</tspan><tspan font-weight="bold" font-style="italic">both</tspan></text>`,
+	},
+	{
+		text: New().Link("https://example.com/?a=1&b=2",
+			New().AppendSpan(Span{
+				FG:      color.NRGBA{R: 0x44, G: 0x77, B: 0xAA, A: 0xff},
+				Content: "passing",
+			})),
+		opts: SVGOptions{
+			Align: AlignRight,
+		},
+		svg: `<text x="0" y="0" text-anchor="end" xml:space="preserve"><a href="https://example.com/?a=1&amp;b=2"><tspan fill="#4477aa">passing</tspan></a></text>`,
+	},
+}
+
+func TestSVG(t *testing.T) {
+	for idx, test := range svgTests {
+		svg := test.text.SVG(test.opts)
+		if svg != test.svg {
+			t.Errorf("%d SVG: got\n%s\nexpected\n%s", idx, svg, test.svg)
+		}
+	}
+}
@@ -1,5 +1,5 @@
 //
-// Copyright (c) 2021 Markku Rossi
+// Copyright (c) 2021-2026 Markku Rossi
 //
 // All rights reserved.
 //
@@ -8,6 +8,8 @@ package text
 
 import (
 	"fmt"
+	"image/color"
+	"strings"
 )
 
 // Text represents a text as a collection of formatted spans with
@@ -27,6 +29,12 @@ func (text *Text) Append(t *Text) *Text {
 	return text
 }
 
+// AppendSpan appends the argument span to the text object.
+func (text *Text) AppendSpan(span Span) *Text {
+	text.Spans = append(text.Spans, span)
+	return text
+}
+
 // Plain appends a plain text span to the text object.
 func (text *Text) Plain(content string) *Text {
 	text.Spans = append(text.Spans, Span{
@@ -92,10 +100,28 @@ func (text *Text) Link(url string, link *Text) *Text {
 	return text
 }
 
-// Span implements a text span with formatting options.
+// String returns the text content without formatting. The links are
+// represented by their link text.
+func (text *Text) String() string {
+	var sb strings.Builder
+	for _, span := range text.Spans {
+		if span.Link != nil {
+			sb.WriteString(span.Link.String())
+		} else {
+			sb.WriteString(span.Content)
+		}
+	}
+	return sb.String()
+}
+
+// Span implements a text span with formatting options. The FG and
+// BG specify optional foreground and background colors; the nil
+// value uses the output medium's default color.
 type Span struct {
 	Bold    bool
 	Oblique bool
+	FG      color.Color
+	BG      color.Color
 	Content string
 	Link    *Text
 }
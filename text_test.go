@@ -1,5 +1,5 @@
 //
-// Copyright (c) 2021 Markku Rossi
+// Copyright (c) 2021-2026 Markku Rossi
 //
 // All rights reserved.
 //
@@ -7,6 +7,7 @@
 package text
 
 import (
+	"image/color"
 	"testing"
 )
 
@@ -35,6 +36,15 @@ var tests = []struct {
 			New().Plain("Markku Rossi")),
 		html: `<a href="https://www.markkurossi.com/">Markku Rossi</a>`,
 	},
+	{
+		text: New().AppendSpan(Span{
+			Bold:    true,
+			FG:      color.NRGBA{R: 0xff, A: 0xff},
+			BG:      color.White,
+			Content: "red",
+		}),
+		html: `<span style="color:#ff0000;background-color:#ffffff"><b>red</b></span>`,
+	},
 }
 
 var htmls = []string{}